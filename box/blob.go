@@ -1,70 +0,0 @@
-// Code generated by go generate; DO NOT EDIT.
-// generated using files from resources directory
-package box
-
-import (
-	"github.com/filecoin-project/go-state-types/abi"
-
-	"github.com/filecoin-project/chain-validation/chain/types"
-)
-
-func init() {
-	resources.Add("/MessageTestAccountActorCreationfailcreateBLSaccountactorinsufficientbalance", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 2, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzacedbiaaw23b3iexjtjuppmtrvyq4gkmaygsbjingnoa7xxfz42h5q4"}})
-	resources.Add("/MessageTestAccountActorCreationfailcreateSECP256K1accountactorinsufficientbalance", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 2, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzacedbiaaw23b3iexjtjuppmtrvyq4gkmaygsbjingnoa7xxfz42h5q4"}})
-	resources.Add("/MessageTestAccountActorCreationsuccesscreateBLSaccountactor", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 1728648}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1728648), Root: "bafy2bzacec3hssodapncjipctzpnmb44ib3xqwju5cu7mh7m463rb7bgpwnda"}})
-	resources.Add("/MessageTestAccountActorCreationsuccesscreateSECP256K1accountactor", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 1670648}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1670648), Root: "bafy2bzaceb2k5gbqnkldn32bxef3j4b5ubjbh4sqsl7bw2f7vgklbfjjjjfrq"}})
-	resources.Add("/MessageTestInitActorSequentialIDAddressCreate", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x69, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2327793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2327793), Root: "bafy2bzacedaxqrutgiftqvbfaulxue4eecahc2r2yucuwssocytj5wlfsjnde"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x6a, 0x55, 0x2, 0x24, 0xe3, 0x7, 0xb3, 0xd6, 0x68, 0x4b, 0xbe, 0xc1, 0xc1, 0x1f, 0x1b, 0x80, 0xa8, 0xaf, 0xe0, 0x4, 0x1d, 0xea, 0x94}, GasUsed: 2352793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2352793), Root: "bafy2bzacec6vq3b2pvya4mynbty4ow2nbrnrcmxoegelbfezbph3zo424q6a2"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesabortduringactorexecution", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x69, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2327793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2327793), Root: "bafy2bzacecaywaghvtm6oe2vcun7637kjs6lz4ae2zftsdd7uymt5i42mfmlw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 16, ReturnValue: []uint8{}, GasUsed: 16910566}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(16910566), Root: "bafy2bzaceaqku2dbsc2n22fgqfzmkxp32pso66boah7x2nzpcyo5xfr2phhco"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesfailnotenoughgastocoveraccountactorcreation", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 1669648}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1669648), Root: "bafy2bzaceamovq3q52l6yyjy3427vbqbc4hyxc3khpea7awlwlgmxy7brvy4i"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1652952}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1652952), Root: "bafy2bzacednwrp3vy6zqktzoa2aiph23p2mek7vdnbeaqt7bkt56zohoyv4du"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1636256}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1636256), Root: "bafy2bzaceaqbh4wtis7mjrxkw3se3ngj3zrpiww4jdsz3dx4dxowjfuvd23gs"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1619560}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1619560), Root: "bafy2bzacebekrzvso66uqkqd6e5caxoronxky6la5liv32rfjvdmyjjhclf2u"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1602864}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1602864), Root: "bafy2bzacecezskgjogut7uuyu4z6jhgle2fifzkcigsgthsvlzcfpi5jft7tw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1586168}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1586168), Root: "bafy2bzacebpx7vdycuqz6wtrq7nid4owc5v66kulcl723pzq2fbpmenl46l72"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1569472}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1569472), Root: "bafy2bzacebjabehu52suxfr7u5qcbjmob6zipw5xqaoepiaoqkcwb74lc2yy2"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1552776}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1552776), Root: "bafy2bzacebkfoprvffwbd5ybzvue7m7bqvlkpbsxfha65ezavqfkiw3qmm642"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1536080}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1536080), Root: "bafy2bzacea65jf3djtm46qlqm4o7kffahmfmwvwkskpizz3ype5cpdop2ppv6"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1519384}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1519384), Root: "bafy2bzacebxzc7mwnubbudviwl3kxkposcgwb3fdkoattxgfi247gnbl27ees"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1502688}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1502688), Root: "bafy2bzaceadfsw2xbvwg554z2w75pvm6atxwklpkre2tg5ezza55jvipgt4yi"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1485992}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1485992), Root: "bafy2bzaced5jrzgm4u5a6pur64my5ygbwgvzoepvh6x5y23srd3e34aw4uwfc"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1469296}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1469296), Root: "bafy2bzacea5in7az77afpwsuxk4yadaw4k3nuxkt3f5wwfcjhutnxuro5trha"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1452600}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1452600), Root: "bafy2bzaceaaaslfxcl7qocmgopbpgi5zejfbnf2cbcfuxsb2rfow2q2kbmmr4"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1435904}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1435904), Root: "bafy2bzacedergwquvlmjkajztsz5tvbrqtpbozargr3ff6gatpj2lqix4e7i6"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1419208}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1419208), Root: "bafy2bzaceagttikiunrmgbx6f3poj4j3jthphf2ex5scro2nxa3hr3mhd525k"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1402512}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1402512), Root: "bafy2bzaceavyiccvgj4eow2jlalobsbfzzjgkvkz2ofx772ho7n56lkgmmdd2"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1385816}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1385816), Root: "bafy2bzacebalzpmk3qqrmvgfyqpenmplozovmd4w6da6l2ndbkb7mo3uwdukk"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1369120}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1369120), Root: "bafy2bzaceaml5ffzr3z6jt6mtirokj3bx3wwbce2qje6kevlj5nsrrkqszrc4"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1352424}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1352424), Root: "bafy2bzaceah24sqxv7dbnttaa7e565qx2ld3qnw4vjdpi7dtquvu4agzxpg3c"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1335728}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1335728), Root: "bafy2bzacededb4hfjt6iastm5unufzdr6bvjxio5isc2643mjeoyrvox26rng"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1319032}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1319032), Root: "bafy2bzaceaiujtkc4wgqnrztmrvfhc2pnmoojeassb3sp2bym56yfvq7xfl2k"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1302336}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1302336), Root: "bafy2bzaceaqfudfcetb4dq555gvw2vkknremg3vkusa4w7fub63uucj4zgi3k"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1285640}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1285640), Root: "bafy2bzacebfag6peww7wic4r67upfgo2o63hzo3x237aehfjlhrfrb3lmyd3g"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1268944}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1268944), Root: "bafy2bzaceafw3dc33gvc73qw4i3tvreej6levtg6kq4iu7asibvsgmjq6ffsi"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1252248}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1252248), Root: "bafy2bzaceca346xyxdzcjxwwp5ru3qsfamypbbtoishxnkbw6lh42p5naxp7g"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1235552}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1235552), Root: "bafy2bzacednjuhc7uddey3lzll5tckxop57i4xwu4wgf4g4ge2ptqxjndbbfa"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1218856}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1218856), Root: "bafy2bzacectjlqnieskk2hxkgbmzxbmibhicnh662imgzkgt35anicocsirce"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1202160}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1202160), Root: "bafy2bzaceahpn6b6dkluzeqrdabmtblztlmq2anqgctb5k6mmvjby42vknnki"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1185464}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1185464), Root: "bafy2bzacebj2leg6ye25zpey5ium7i756yr75m5zjwhapwaz4zibhqmzvemgk"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1168768}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1168768), Root: "bafy2bzacebutqxxqwmae2ae5rzu3iztnu5p6nekilqnz7c6c4654mfkpp4rle"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1152072}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1152072), Root: "bafy2bzaceabuxkkfazjg4hjqgesmhvqp3posfeq2hc6tuaharldcrfde7bnug"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1135376}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1135376), Root: "bafy2bzacedaqhk2gghlqpgew7sja25ody5afe44fsyeql4stivipzociyaurw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1118680}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1118680), Root: "bafy2bzacedauzdtofrsyltb6vm3jaf3cs4rkco4gjjmyeoij726omsd36i5ti"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1101984}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1101984), Root: "bafy2bzacecpdrhnmpxxdo5yt2kiqi6yzfsppyiflrxo5e5opae72zl2kyfwlo"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1085288}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1085288), Root: "bafy2bzacec7j6xljj465gyvdmqldxmoa2hkl6cgkvi6j4iw6ektuljfpwv3ie"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1068592}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1068592), Root: "bafy2bzacedocfrojua2pq75q7lqufqlzrccudedkkn2el2m6afczcxvfjdjcs"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1051896}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1051896), Root: "bafy2bzacecxof67c75blpnjxlk4yuw56akcm3lbliaslszn2oefwhbovtsoeo"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1035200}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1035200), Root: "bafy2bzaced4ezrmcuyl7mjjb7vxmn4c43e46r3ljrnl7a2qiizcjpcqyybvwk"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1018504}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1018504), Root: "bafy2bzaceavv6jf4smrwmwqqjksnzewyyqh6v5qxp5nb6lhdtq2retnwpwzd2"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 1001808}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1001808), Root: "bafy2bzacec6q63mcttkiks6t45c53iigabaaluybwhaebke6oocof7jme5t3i"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 985112}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(985112), Root: "bafy2bzacebrxbyf7k7vfmbyijy6s3htnxejk3is3yykjdibnxsa5bfuzz3ddm"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 968416}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(968416), Root: "bafy2bzaceavpjzcb5fn4k2ymi5s3enectkqe5viqnegfry7nglnmcoaafc6da"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 951720}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(951720), Root: "bafy2bzacebat4kaq3gycxdddd5oub4eqfhxr5yg3zu2fe4yfq36zp2trt73d2"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 935024}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(935024), Root: "bafy2bzaceduzm4klknqdmeto7tunpaiukr6huop56b3qma4zrkl2yqegljgjo"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 918328}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(918328), Root: "bafy2bzacedi55rul7wmma563hldyzlkkrl6hjg76g5pppqg3xkkkxawlken3a"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 901632}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(901632), Root: "bafy2bzacea6mhyqwxszouzz4qgzebqexkiq3kincxdoekxxdljgf5lpu2aqck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 884936}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(884936), Root: "bafy2bzacedvkos3jksqnsckyes3dx3rxg4h4ihzy2tmqhxyunpmg7iokq733m"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 868240}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(868240), Root: "bafy2bzaceagrb5cihcxt7jf66hhvnbsxkfjethh5vigpcmlst3y4mfysw5fwk"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 851544}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(851544), Root: "bafy2bzacec2pbaa4rkayodd7jnv4fga6eg6inf7oqnj6sjej22oixdwycenls"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 834848}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(834848), Root: "bafy2bzaceda4fi2t7rmytnf2n6qzl4ueebvuzpai3mgjjarqjzh6c5ejhbdlu"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 818152}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(818152), Root: "bafy2bzacebse2kwm6qnfpsiwvpwizhxcjicspybcbgiqs3zjifubcehy65ts4"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 801456}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(801456), Root: "bafy2bzaceddgg3yqym4sppswhj4b4syfgrtlatoeow2bapxq64v35kq3tcqn6"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 784760}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(784760), Root: "bafy2bzacebn65kpnkmpxux3cpj56rvt3uxbvo2cwconz4zuodfbg7qhb3dtam"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 768064}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(768064), Root: "bafy2bzacearqevaxhzjvhdaeqb5di5apy26ymsxg6nszyvfoxyykoevjntpho"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 751368}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(751368), Root: "bafy2bzaceb26qx23enxmfbf3djk2ykpichok6arcbd7goi6n67enhduhhc4ig"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 734672}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(734672), Root: "bafy2bzacedwrigo3nqaklobuzcvcfeuzokl4rxnzyynzimf3zein6apqlikqk"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 717976}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(717976), Root: "bafy2bzacedr6bad6wufaaphq26ehapswfs6vsfpwbid7rr4qj52c4wwf5ewwi"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 701280}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(701280), Root: "bafy2bzacedaikvrhsmpdsgrrgiqxvngguivgh5mwgp4h6kyffhhbcwf5bfn5s"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 684584}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(684584), Root: "bafy2bzaceb6ue2wxlmb42ponzkzsqpswicinqeaqtee4azase4hxistei2lyg"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 667888}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(667888), Root: "bafy2bzacedii7iuausvfbjmxqgmkzqccl3o5qy4qsa2ggufu36wk55e2q3k52"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 651192}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(651192), Root: "bafy2bzacebzftuixt6zsth67wgekvhhh3bhyyudembpkp5lwnllqvcpnka5lc"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 634496}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(634496), Root: "bafy2bzaced67gdsdbhg3t2pboxu3h4b733loxfrqhun5ywwop4pplb57si5nm"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 617800}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(617800), Root: "bafy2bzaceblnccek5jqjfarkt3sz5n3pkyxw2ggwd7kbljkjvzwwozekq3aug"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 601104}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(601104), Root: "bafy2bzacebuw2bxaasehymk2zi5bldrehopfauu42vwj2ievlpihmx3b5a3vo"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 584408}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(584408), Root: "bafy2bzacebgrh6kr6bvzturjokm6sx3min5zaylh36m62vrnlwykoiqhk76om"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 567712}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(567712), Root: "bafy2bzaceaqpkitzixic5ko3oyy4si7lrvpcrurnqlnkgeu5dk6l3zjaib5ri"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 551016}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(551016), Root: "bafy2bzaceczya55ampqugrple3o3ucgfvzcafhjupczjpnglticyrcre6rdyw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 534320}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(534320), Root: "bafy2bzacea4icf3wzludchhzliep3ciahn3vz4kvkbshrmxdhq5ixevaguoos"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 517624}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(517624), Root: "bafy2bzaceb7cjro2hlbwkcqvpddjoz7lm7wxg5zttx22wkauotul6ao5qn26o"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 500928}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(500928), Root: "bafy2bzaceafrcandyvf65phrynl4lfw2ds55zisyo2pt2bqgkutq2kljnrvvc"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 484232}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(484232), Root: "bafy2bzaceckijskvqn5mtu35ld4xzjduq3w5wyloy3bu6uamazrgjnkl22ijo"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 467536}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(467536), Root: "bafy2bzaced33eo6n7hppz6yzistjoe6dtnugeftjvfpbxerr2awckro3jid2a"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 450840}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(450840), Root: "bafy2bzacedelrl4wro7m2g6m5m2lluylst7trzkirwfvf324qf5xqrv2rcezg"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 434144}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(434144), Root: "bafy2bzacecpkwoga4ds7sbazartbojkbmfb7zrn7oyhkmfm33wbhsxdw7m7jm"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 417448}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(417448), Root: "bafy2bzaceasapmrr3expxudzzisewj3rnurwn77kck7ltmjnfdwnan4dyjbau"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 400752}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(400752), Root: "bafy2bzacedhi4rxiifbn5tgcd2tvqsrbaoaivclqofeloj4erwp56x72qeucy"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 384056}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(384056), Root: "bafy2bzacecdw6a52kplyzwpf6mefxvnmccoorrfvvf3q2bxlgii7cur25efye"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 367360}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(367360), Root: "bafy2bzaced4rchyu6i3u4pzljdzwvdolh7g74jnapy2uizdw4h3eflhoxo4lq"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 350664}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(350664), Root: "bafy2bzacebp3m7llwb7h6mf7ut46kgaitmxfuv4d67iao5mg6wp5vxzvnwxgm"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 333968}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(333968), Root: "bafy2bzaceb4cgftxbsff6q63kw4n7cc2lfs6evi64t2purh3yqejgr4y7ezuu"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 317272}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(317272), Root: "bafy2bzaceb6zbczccwl6zs3hgbhcxoazat2mukpxym2vi5mfve6uc7k6x2apg"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 300576}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(300576), Root: "bafy2bzacecky733plyp5lsnpbzm5nftezdjbx24ftiocmnykanl5xa3ybc27o"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 283880}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(283880), Root: "bafy2bzacecbj6cijwguasqtgsv3kmok27tizr2ejeojta454xdsre6xqxshcy"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 267184}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(267184), Root: "bafy2bzaceawcd7jv7zbwxnngxqu7s2zy6w2qbjnudizbu77lxlnm33w5pustc"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 250488}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(250488), Root: "bafy2bzaceaxfpm4tkj7r32ksizsvbtpth6rrnw3tydykf2qab33p3vz5ktsnu"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 233792}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(233792), Root: "bafy2bzacedcgphtdpfdthkgc6vvhkajoeytl7agjf6b5ial3pa6c23lru4pyw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 217096}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(217096), Root: "bafy2bzacec6xo5gmb6z73u6mwjvxaoyj7himvecscpq2zo42zl5pfwjhldwiw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 200400}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(200400), Root: "bafy2bzacebukwb23gg2il2t7xaca6qkchrlewy5abx6aux2hob53stqfiao2m"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 183704}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(183704), Root: "bafy2bzacedxvoxwezjc7yimmyqujec6xpfxxa3cytqtlpaugum5au7gmakikg"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 167008}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(167008), Root: "bafy2bzacebsikhb6ozz2rgxdkiyjib7gfnc3utlowzhddv7rm5eo4gswdq3ha"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 150312}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(150312), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13886300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13886300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13886300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13886300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13886300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13686300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13686300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13686300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13586300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceaiiqkys2td7q5jh5bowc2xbsjyvy64r5kc7xfiy4d2ssv6rvqsck"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesfailtocovergascostformessagereceiptonchain", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13386300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceauhpbxjcudqphre4c3kdjakdgmbbyklztytu2isheiqwn3ogq62i"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesinvalidactorCallSeqNum", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 2, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceauhpbxjcudqphre4c3kdjakdgmbbyklztytu2isheiqwn3ogq62i"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceauhpbxjcudqphre4c3kdjakdgmbbyklztytu2isheiqwn3ogq62i"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesinvalidmethodforreceiver", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 3, ReturnValue: []uint8{}, GasUsed: 160719}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(160719), Root: "bafy2bzacea652w7hnlfygpsrbkuxapxhcn2sstphxknhadrh3dcdttktjmlmm"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesnotenoughgastopaymessageonchainsizecost", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(13386300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceauhpbxjcudqphre4c3kdjakdgmbbyklztytu2isheiqwn3ogq62i"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(11786300), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceauhpbxjcudqphre4c3kdjakdgmbbyklztytu2isheiqwn3ogq62i"}})
-	resources.Add("/MessageTestMessageApplicationEdgecasesreceiverIDActoraddressdoesnotexist", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 5, ReturnValue: []uint8{}, GasUsed: 1306317}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1306317), Root: "bafy2bzacea652w7hnlfygpsrbkuxapxhcn2sstphxknhadrh3dcdttktjmlmm"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 5, ReturnValue: []uint8{}, GasUsed: 1322317}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1322317), Root: "bafy2bzacedfr6cviytny5rtrbslxrj5vvncgqaurtf2lpbpnf5rerzfy2bgaa"}})
-	resources.Add("/MessageTestMultiSigActoraddsigner", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x69, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2279793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2279793), Root: "bafy2bzacecyxmh6dhrvvjq6uexoccd5jddl5ltqfmefts7jsnapxslyvpub3c"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 8, ReturnValue: []uint8{}, GasUsed: 145719}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(145719), Root: "bafy2bzaceab6xgkln2hezxgvj7tjjlec7irzilkrmotgcn3nhutusvbungnd2"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x0, 0x40}, GasUsed: 1570759}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1570759), Root: "bafy2bzacedmahwzjhhbteyaftztufvflvf42g5ugokf3f6l3tsw6usrdq7pnm"}})
-	resources.Add("/MessageTestMultiSigActorconstructortest", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2247793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2247793), Root: "bafy2bzacec4d4s4v6aevhrqdc4htgpul3kzik22cjsocos2ecjjrwrr4lpf5m"}})
-	resources.Add("/MessageTestMultiSigActorproposeandapprove", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x6a, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2312793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2312793), Root: "bafy2bzaceanmgymv2hwlamxcseka6j6xa5nw6p2kj7akff3tvvtawgwuk7bai"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf4, 0x0, 0x40}, GasUsed: 1012967}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1012967), Root: "bafy2bzaced373umdx3afoud6gbsidt2uadr5nctt6ree6ik4buznc3mtmh6rw"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 18, ReturnValue: []uint8{}, GasUsed: 244961}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(244961), Root: "bafy2bzacebeg33prfz6g2u5c356npbhl76zj36srv6xau64vllcllg2t4vdcu"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 18, ReturnValue: []uint8{}, GasUsed: 252961}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(252961), Root: "bafy2bzacecr2zgtuduovpdkuzx4dwwdml5frlfhc6h55vz3bqsox5oac2gog6"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x83, 0xf5, 0x0, 0x40}, GasUsed: 1525281}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1525281), Root: "bafy2bzacebxy2ofqggderwxelsentu3uoqha5uc7rrtsvnc33u4y4ujk4ydxg"}})
-	resources.Add("/MessageTestMultiSigActorproposeandcancel", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x6a, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2312793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2312793), Root: "bafy2bzacecwpbcxk7dqu47bpqvtcfjthlsu2rcy6eagqt2iu6nblaumam4doa"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf4, 0x0, 0x40}, GasUsed: 1012967}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1012967), Root: "bafy2bzacebwsy6nmcirps6tb3lho7rkmjjcjt44bauhtvt7heighfdj47wmc6"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 18, ReturnValue: []uint8{}, GasUsed: 328203}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(328203), Root: "bafy2bzacedszyvcftvr5lknk2ysj42vf6gqqmkkn2dntgp4zdvhtfrpil7d2g"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 588698}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(588698), Root: "bafy2bzacea25chcvmgoxy4fe45xrhffwqwpcgx6cfjijv23ccz23vpyfbi32o"}})
-	resources.Add("/MessageTestNestedSendsfailabortedexec", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x12, 0x40}, GasUsed: 3293381}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(3293381), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailinnerabort", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x8, 0x40}, GasUsed: 1372947}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1372947), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailinsufficientfundsfortransferininnersend", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x40, 0x6}, GasUsed: 389124}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(389124), Root: "bafy2bzaced4g7dueck5tyhkqy5g7odwnr5w4mnssozssrtjpubla74dob7h6w"}})
-	resources.Add("/MessageTestNestedSendsfailinvalidmethodnumforactor", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x3, 0x40}, GasUsed: 1354947}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1354947), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailinvalidmethodnumnewactor", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x3, 0x40}, GasUsed: 2728327}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2728327), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailmismatchedparams", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x1, 0x40}, GasUsed: 1372947}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1372947), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailmissingparams", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x1, 0x40}, GasUsed: 1351947}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1351947), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailnonexistentIDaddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x5, 0x40}, GasUsed: 2488045}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2488045), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsfailnonexistentactoraddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x5, 0x40}, GasUsed: 2543045}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2543045), Root: "bafy2bzaceccjefezxegr56awtdexej753duzkcadcgdrgvziakmvfeme2nski"}})
-	resources.Add("/MessageTestNestedSendsokbasic", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x0, 0x40}, GasUsed: 1574996}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1574996), Root: "bafy2bzacedrnu56c437hucnwazoqvldhmwg6xaxl4hjhyd7h2vi6ekxlegtgm"}})
-	resources.Add("/MessageTestNestedSendsoknonCBORparamswithtransfer", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x0, 0x40}, GasUsed: 2902376}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2902376), Root: "bafy2bzacedrnu56c437hucnwazoqvldhmwg6xaxl4hjhyd7h2vi6ekxlegtgm"}})
-	resources.Add("/MessageTestNestedSendsokrecursive", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x0, 0x40}, GasUsed: 1551759}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1551759), Root: "bafy2bzaceahzn2dhd2oo66olvsjvh7nzzkgcbmb3h5isj65cq5ascl7ozwfbs"}})
-	resources.Add("/MessageTestNestedSendsoktonewactor", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x0, 0x40}, GasUsed: 2890376}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2890376), Root: "bafy2bzacedrnu56c437hucnwazoqvldhmwg6xaxl4hjhyd7h2vi6ekxlegtgm"}})
-	resources.Add("/MessageTestNestedSendsoktonewactorwithinvoke", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x68, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2229793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2229793), Root: "bafy2bzaceb4jkp3fbjyxppk2s7bkcf3ch55arnn75frs6lsf3iisrdcxsbcea"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x84, 0x0, 0xf5, 0x0, 0x56, 0x55, 0x1, 0x2a, 0x93, 0x3c, 0x5f, 0xa9, 0xec, 0xd8, 0x54, 0xa9, 0x80, 0x7d, 0xa3, 0x78, 0x99, 0xac, 0xc5, 0xa8, 0x7e, 0x38, 0x36}, GasUsed: 2822569}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2822569), Root: "bafy2bzacedrnu56c437hucnwazoqvldhmwg6xaxl4hjhyd7h2vi6ekxlegtgm"}})
-	resources.Add("/MessageTestPaychhappypathcollect", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x69, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2327793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2327793), Root: "bafy2bzacedaxqrutgiftqvbfaulxue4eecahc2r2yucuwssocytj5wlfsjnde"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 17244190}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(17244190), Root: "bafy2bzacedawus2qjciu3tu57pwyhyd34725lx6jwbetmtafk75jivowlgpl6"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 358031}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(358031), Root: "bafy2bzaceamyvjjf75rfxpcik4m27chsceoyj6w33f2xl4krytb2s3hqdabdq"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 356366}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(356366), Root: "bafy2bzacecdqmrxjnbuzq42gwmzxxmtkgg4jpjw4puffgjp3iumf7e4oof2nw"}})
-	resources.Add("/MessageTestPaychhappypathconstructor", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x69, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2327793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2327793), Root: "bafy2bzacedaxqrutgiftqvbfaulxue4eecahc2r2yucuwssocytj5wlfsjnde"}})
-	resources.Add("/MessageTestPaychhappypathupdate", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x82, 0x42, 0x0, 0x69, 0x55, 0x2, 0x6b, 0x15, 0x6c, 0xef, 0x87, 0xc2, 0x1, 0x9d, 0x65, 0x2f, 0x92, 0x67, 0x83, 0x6, 0x36, 0xb4, 0xd2, 0x4f, 0xac, 0xe1}, GasUsed: 2327793}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(2327793), Root: "bafy2bzacedaxqrutgiftqvbfaulxue4eecahc2r2yucuwssocytj5wlfsjnde"}, types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 17506330}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(17506330), Root: "bafy2bzaceawvucbyhv6zilbs5qobhew5asq4dkd4z3jbvhsgezdeazcazv6pc"}})
-	resources.Add("/MessageTestValueTransferAdvancefailtotransferfromunknownaccounttoknownaddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceauhpbxjcudqphre4c3kdjakdgmbbyklztytu2isheiqwn3ogq62i"}})
-	resources.Add("/MessageTestValueTransferAdvancefailtotransferfromunknownaddresstounknownaddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzaceccnei3gdexjvl3v4lv6nekz5jfzii5dwwq42p6cdzmbbkwitjgts"}})
-	resources.Add("/MessageTestValueTransferAdvanceoktransferfromknownaddresstonewaccount", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 1669648}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(1669648), Root: "bafy2bzacebvaorfce3bbfh6xyjqwmgnwemu5yebgzjopseisx24hvzdmlf26g"}})
-	resources.Add("/MessageTestValueTransferAdvanceselftransferidtoidaddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 316268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(316268), Root: "bafy2bzacea652w7hnlfygpsrbkuxapxhcn2sstphxknhadrh3dcdttktjmlmm"}})
-	resources.Add("/MessageTestValueTransferAdvanceselftransferidtosecpaddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 335268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(335268), Root: "bafy2bzacea652w7hnlfygpsrbkuxapxhcn2sstphxknhadrh3dcdttktjmlmm"}})
-	resources.Add("/MessageTestValueTransferAdvanceselftransfersecptoidaddress", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 335268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(335268), Root: "bafy2bzacea652w7hnlfygpsrbkuxapxhcn2sstphxknhadrh3dcdttktjmlmm"}})
-	resources.Add("/MessageTestValueTransferAdvanceselftransfersecptosecp", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 354268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(354268), Root: "bafy2bzacea652w7hnlfygpsrbkuxapxhcn2sstphxknhadrh3dcdttktjmlmm"}})
-	resources.Add("/MessageTestValueTransferSimplefailtotransfermorefundsthansenderbalance0", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 6, ReturnValue: []uint8{}, GasUsed: 359268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(359268), Root: "bafy2bzaceazdv5pdhz4xpxmfr5oilbxqlw63bknxlc2ldmrrf3lvi5uoizlxu"}})
-	resources.Add("/MessageTestValueTransferSimplefailtotransfermorefundsthansenderhaswhensenderbalancematchesgaslimit", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 6, ReturnValue: []uint8{}, GasUsed: 354268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(354268), Root: "bafy2bzacecfx2ew3auh6ib4c62yjewrslfb7xl5xiw72qzjvaded5luaeqrbc"}})
-	resources.Add("/MessageTestValueTransferSimplefailtotransferwhensenderbalanceundergaslimit", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 2, ReturnValue: []uint8{}, GasUsed: 0}, Penalty: abi.NewTokenAmount(100000000000), Reward: abi.NewTokenAmount(0), Root: "bafy2bzacedxb47pfcqtboxyx3h5rfbcdcpaenjc3q4wzu6e6hz3qvpxmxxfiu"}})
-	resources.Add("/MessageTestValueTransferSimplesuccessfullytransferfundsfromsendertoreceiver", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 354268}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(354268), Root: "bafy2bzacedfuwhc4bxbxl2ofxgc46m4puamh2ylwveqoyvgg4xv7gkzlztzqi"}})
-	resources.Add("/MessageTestValueTransferSimplesuccessfullytransferzerofundsfromsendertoreceiver", []types.ApplyMessageResult{types.ApplyMessageResult{Receipt: types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 165096}, Penalty: abi.NewTokenAmount(0), Reward: abi.NewTokenAmount(165096), Root: "bafy2bzaceazdv5pdhz4xpxmfr5oilbxqlw63bknxlc2ldmrrf3lvi5uoizlxu"}})
-	resources.Add("/TipSetTestBlockMessageApplicationSECPandBLSmessagescostdifferentamountsofgas", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 412268}, types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 423268}}, Root: "bafy2bzacedzlfpt3ppo5d5ognlljuwfwal5kk4bv6b2bdwiwre4pfu6oeeixk"}})
-	resources.Add("/TipSetTestBlockMessageDeduplicationapplyaduplicatedBLSmessage", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 354268}}, Root: "bafy2bzaced2dwf5m3rbz2uy3b4hcgsfamep7e5nio7khrgo6awdyxnezpwafe"}})
-	resources.Add("/TipSetTestBlockMessageDeduplicationapplyasingleBLSmessage", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 354268}}, Root: "bafy2bzaced2dwf5m3rbz2uy3b4hcgsfamep7e5nio7khrgo6awdyxnezpwafe"}})
-	resources.Add("/TipSetTestBlockMessageDeduplicationapplyasingleSECPmessage", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 423268}}, Root: "bafy2bzaced2dwf5m3rbz2uy3b4hcgsfamep7e5nio7khrgo6awdyxnezpwafe"}})
-	resources.Add("/TipSetTestBlockMessageDeduplicationapplyduplicateBLSandSECPmessage", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 316268}}, Root: "bafy2bzaced2dwf5m3rbz2uy3b4hcgsfamep7e5nio7khrgo6awdyxnezpwafe"}})
-	resources.Add("/TipSetTestBlockMessageDeduplicationapplyduplicateSECPmessage", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 423268}}, Root: "bafy2bzaced2dwf5m3rbz2uy3b4hcgsfamep7e5nio7khrgo6awdyxnezpwafe"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiesinsufficientgastocoverreturnvalue", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{0x83, 0x42, 0x0, 0x64, 0x42, 0x0, 0x65, 0x80}, GasUsed: 328203}}, Root: "bafy2bzaceastl2yzkimhzretrpiysgkv3ccdymzeks2eoe2xq2dg6ror4g5yy"}, types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 7, ReturnValue: []uint8{}, GasUsed: 328202}}, Root: "bafy2bzaceb3dd7f6u6ixfk2rpzyklsmgzwawpw7vjycaufnqolerovkghosl2"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiesnopenaltyifthebalanceisnotsufficienttocovertransfer", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 321268}, types.MessageReceipt{ExitCode: 6, ReturnValue: []uint8{}, GasUsed: 321268}}, Root: "bafy2bzacebzimtzuw3bardp4crktowo6audlfdrg5dqycnk5gpcqyrbighee4"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiesoksimplesend", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 354268}, types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 354268}}, Root: "bafy2bzaceby4tiq56nadjr2ym6cptqkj3enwb2ilhnnmu3xyaxdr2o2q45ki2"}, types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 335268}, types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 335268}}, Root: "bafy2bzaceaiorzhkpkimkbhokwlkhoxt32buwur5e2tf4v4kpiz6lspohntsc"}, types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 335268}, types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 335268}}, Root: "bafy2bzaceadlvgjnlbsbuszx2jnmjika334odjwe5zatyzesjtsi3cvo5maey"}, types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 316268}, types.MessageReceipt{ExitCode: 0, ReturnValue: []uint8{}, GasUsed: 316268}}, Root: "bafy2bzacec57j63otl3m3z2m3xcwvtge2t36typ6dp5t4bpwfhsi35n7a5bk4"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiespenalizesenderdoesntexist", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}}, Root: "bafy2bzacebtw5lb3cu4g55apipdrq562drehz6zre5f5uislfmsqudaa5mjeq"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiespenalizesendernonaccount", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}, types.MessageReceipt{ExitCode: 1, ReturnValue: []uint8{}, GasUsed: 0}}, Root: "bafy2bzacebtw5lb3cu4g55apipdrq562drehz6zre5f5uislfmsqudaa5mjeq"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiespenalizewrongcallseqnum", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 2, ReturnValue: []uint8{}, GasUsed: 0}}, Root: "bafy2bzacedvokb2dvrygtdd7tlsetrgadxitvoqujqqvmgl4boib5rhor7yzi"}})
-	resources.Add("/TipSetTestMinerRewardsAndPenaltiespenaltyifthebalanceisnotsufficienttocovergas", []types.ApplyTipSetResult{types.ApplyTipSetResult{Receipts: []types.MessageReceipt{types.MessageReceipt{ExitCode: 2, ReturnValue: []uint8{}, GasUsed: 0}}, Root: "bafy2bzacebdz7tmlqbursha3fafva7s6zuypvfbcycaojvl3iwnvgqcmozcay"}})
-}
@@ -1,52 +1,180 @@
-//go:generate go run gen.go
+package box
 
-// Based on code from https://github.com/wso2/product-apim-tooling/tree/6292e88350868ddaa17ec58501672f008639dd5a/import-export-cli on 2020-03-21 under the Apache License 2.0.
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 
-package box
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// SchemaVersion is the version of the expectation file format written by this package.
+// Files with any other version are rejected rather than misinterpreted.
+const SchemaVersion = 1
+
+// DefaultProfile is the profile holding the expectations recorded against the reference implementation.
+const DefaultProfile = "default"
 
-type resourceBox struct {
-	storage map[string]interface{}
+// The expectations shipped with chain-validation, laid out as resources/<profile>/<key>.json.
+//
+//go:embed resources
+var embedded embed.FS
+
+// Expectations holds the results recorded for a single test, in the order they were applied.
+type Expectations struct {
+	Version int      `json:"version"`
+	Results []Result `json:"results"`
+}
+
+// Result is the outcome of a single ApplyMessage or ApplyTipSetMessages call. Exactly one field is set.
+type Result struct {
+	Message *types.ApplyMessageResult `json:"message,omitempty"`
+	TipSet  *types.ApplyTipSetResult  `json:"tipset,omitempty"`
 }
 
-func newResourceBox() *resourceBox {
-	return &resourceBox{storage: make(map[string]interface{})}
+// NewExpectations returns empty expectations using the current schema version.
+func NewExpectations() *Expectations {
+	return &Expectations{Version: SchemaVersion}
 }
 
-// Find a file
-func (r *resourceBox) Has(file string) bool {
-	if _, ok := r.storage[file]; ok {
-		return true
+// Add appends a result to the expectations.
+func (e *Expectations) Add(result types.Trackable) error {
+	switch r := result.(type) {
+	case types.ApplyMessageResult:
+		e.Results = append(e.Results, Result{Message: &r})
+	case types.ApplyTipSetResult:
+		e.Results = append(e.Results, Result{TipSet: &r})
+	default:
+		return fmt.Errorf("unknown result type: %T", result)
 	}
-	return false
+	return nil
 }
 
-// Get file's content
-func (r *resourceBox) Get(file string) (interface{}, bool) {
-	if f, ok := r.storage[file]; ok {
-		return f, ok
+func (e *Expectations) validate() error {
+	if e.Version != SchemaVersion {
+		return fmt.Errorf("unsupported schema version %d, expected %d", e.Version, SchemaVersion)
+	}
+	for i, r := range e.Results {
+		if (r.Message == nil) == (r.TipSet == nil) {
+			return fmt.Errorf("result %d must hold exactly one of message or tipset", i)
+		}
 	}
-	return nil, false
+	return nil
 }
 
-// Add a file to box
-func (r *resourceBox) Add(file string, content interface{}) {
-	r.storage[file] = content
+// Store provides the expectations of every test, grouped into named profiles.
+type Store struct {
+	fsys fs.FS
 }
 
-// Resource expose
-var resources = newResourceBox()
+// NewStore returns a store reading expectations from `fsys`, laid out as <profile>/<key>.json.
+func NewStore(fsys fs.FS) *Store {
+	return &Store{fsys: fsys}
+}
+
+// DirStore returns a store reading expectations from a directory on disk.
+func DirStore(dir string) *Store {
+	return NewStore(os.DirFS(dir))
+}
 
-// Get a file from box
-func Get(file string) (interface{}, bool) {
-	return resources.Get(file)
+// Embedded returns the store of expectations shipped with chain-validation.
+func Embedded() *Store {
+	sub, err := fs.Sub(embedded, "resources")
+	if err != nil {
+		panic(err)
+	}
+	return NewStore(sub)
 }
 
-// Add a file content to box
-func Add(file string, content interface{}) {
-	resources.Add(file, content)
+// Profiles returns the names of the profiles in the store.
+func (s *Store) Profiles() ([]string, error) {
+	entries, err := fs.ReadDir(s.fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	for _, e := range entries {
+		if e.IsDir() {
+			profiles = append(profiles, e.Name())
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
 }
 
-// Has a file in box
-func Has(file string) bool {
-	return resources.Has(file)
+// Keys returns the keys of every test with expectations in `profile`.
+func (s *Store) Keys(profile string) ([]string, error) {
+	var keys []string
+	err := fs.WalkDir(s.fsys, profile, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != ".json" {
+			return nil
+		}
+		keys = append(keys, strings.TrimSuffix(strings.TrimPrefix(p, profile), ".json"))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Get returns the expectations recorded for `key` in `profile`. Found is false if there are none.
+func (s *Store) Get(profile, key string) (exp *Expectations, found bool, err error) {
+	raw, err := fs.ReadFile(s.fsys, Path(profile, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	exp = new(Expectations)
+	if err := json.Unmarshal(raw, exp); err != nil {
+		return nil, false, fmt.Errorf("decoding expectations for %s in profile %s: %w", key, profile, err)
+	}
+	if err := exp.validate(); err != nil {
+		return nil, false, fmt.Errorf("expectations for %s in profile %s: %w", key, profile, err)
+	}
+	return exp, true, nil
+}
+
+// Path returns the slash-separated path of the expectations for `key` in `profile`, relative to the store root.
+func Path(profile, key string) string {
+	return path.Join(profile, key+".json")
+}
+
+// Write saves expectations for `key` in `profile` to the store rooted at directory `dir`.
+func Write(dir, profile, key string, exp *Expectations) error {
+	if err := exp.validate(); err != nil {
+		return err
+	}
+	file := filepath.Join(dir, filepath.FromSlash(Path(profile, key)))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(exp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(raw, '\n'), 0644)
+}
+
+// Get returns the expectations for `key` in the default profile of the embedded store.
+func Get(key string) (*Expectations, bool) {
+	exp, found, err := Embedded().Get(DefaultProfile, key)
+	if err != nil {
+		panic(err)
+	}
+	return exp, found
 }
@@ -0,0 +1,35 @@
+package box_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/box"
+	"github.com/filecoin-project/chain-validation/tracker"
+)
+
+func TestEmbeddedExpectationsAreValid(t *testing.T) {
+	store := box.Embedded()
+	profiles, err := store.Profiles()
+	require.NoError(t, err)
+	require.Contains(t, profiles, box.DefaultProfile)
+
+	for _, profile := range profiles {
+		keys, err := store.Keys(profile)
+		require.NoError(t, err)
+		require.NotEmpty(t, keys, profile)
+
+		for _, key := range keys {
+			parsed, err := tracker.ParseKey(key)
+			require.NoError(t, err)
+			assert.Equal(t, key, parsed.String())
+
+			exp, found, err := store.Get(profile, key)
+			require.NoError(t, err, key)
+			require.True(t, found, key)
+			assert.NotEmpty(t, exp.Results, key)
+		}
+	}
+}
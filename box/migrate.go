@@ -0,0 +1,141 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/chain-validation/box"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/tracker"
+)
+
+// This tool converts expectation files recorded under the legacy flat naming scheme, one JSON result per line, into
+// expectation files of the current schema stored under their hierarchical keys.
+// Legacy names dropped every character other than letters and numbers, so the tool needs the full names of the
+// tests to recover the keys. These are read from the verbose output of a test run on stdin, e.g.
+//
+//	go test -v -run TestChainValidation ./... | go run migrate.go -legacy old-resources -resources resources
+//
+// Legacy files shared by more than one test cannot be attributed and are reported and left in place; those
+// tests must be recorded again.
+func main() {
+	legacyDir := flag.String("legacy", "legacy", "directory holding the legacy expectation files")
+	resources := flag.String("resources", "resources", "directory to write the converted expectations to")
+	profile := flag.String("profile", box.DefaultProfile, "profile to write the converted expectations to")
+	dryRun := flag.Bool("dry-run", false, "report the migration without writing any files")
+	flag.Parse()
+
+	// legacy key -> keys of every test that maps to it
+	candidates := make(map[string]map[string]struct{})
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "=== RUN") {
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(line, "=== RUN"))
+		key, err := tracker.KeyFromTestName(name)
+		if err != nil {
+			// harness tests and tests outside of the suites have no expectations.
+			continue
+		}
+		legacy := tracker.LegacyKey(name)
+		if candidates[legacy] == nil {
+			candidates[legacy] = make(map[string]struct{})
+		}
+		candidates[legacy][key.String()] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal("Error reading test names:", err)
+	}
+
+	infos, err := ioutil.ReadDir(*legacyDir)
+	if err != nil {
+		log.Fatal("Error reading legacy directory:", err)
+	}
+
+	var migrated, ambiguous, unknown int
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		legacy := "/" + info.Name()
+		keys := candidates[legacy]
+		switch len(keys) {
+		case 0:
+			log.Printf("no test maps to %s, skipping", legacy)
+			unknown++
+		case 1:
+			var key string
+			for k := range keys {
+				key = k
+			}
+			log.Printf("%s -> %s", legacy, key)
+			if !*dryRun {
+				convertFile(filepath.Join(*legacyDir, info.Name()), *resources, *profile, key)
+			}
+			migrated++
+		default:
+			var names []string
+			for k := range keys {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			log.Printf("%s is shared by %d tests and cannot be migrated, record them again: %s", legacy, len(names), strings.Join(names, ", "))
+			ambiguous++
+		}
+	}
+
+	log.Printf("migrated: %d, ambiguous: %d, unknown: %d", migrated, ambiguous, unknown)
+	if ambiguous > 0 {
+		os.Exit(1)
+	}
+}
+
+func convertFile(legacyFile, resources, profile, key string) {
+	if _, err := os.Stat(filepath.Join(resources, filepath.FromSlash(box.Path(profile, key)))); err == nil {
+		log.Fatalf("Refusing to overwrite existing expectations for %s", key)
+	}
+
+	f, err := os.Open(legacyFile)
+	if err != nil {
+		log.Fatal("Error opening legacy file:", err)
+	}
+	defer f.Close()
+
+	// legacy files hold a single kind of result, determined by the suite of the test.
+	tipsets := strings.HasPrefix(key, "/TipSetTest/")
+	exp := box.NewExpectations()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var res box.Result
+		if tipsets {
+			res.TipSet = new(types.ApplyTipSetResult)
+			err = json.Unmarshal(scanner.Bytes(), res.TipSet)
+		} else {
+			res.Message = new(types.ApplyMessageResult)
+			err = json.Unmarshal(scanner.Bytes(), res.Message)
+		}
+		if err != nil {
+			log.Fatalf("Error decoding %s: %s", legacyFile, err)
+		}
+		exp.Results = append(exp.Results, res)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal("Error reading legacy file:", err)
+	}
+
+	if err := box.Write(resources, profile, key, exp); err != nil {
+		log.Fatal("Error writing expectations:", err)
+	}
+}
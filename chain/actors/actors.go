@@ -0,0 +1,220 @@
+package actors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
+	cbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// Version identifies a release of the builtin actors, each with its own code CIDs and state schemas.
+//
+// Version0 is the only version released. Suites and checks decoding states with the v0 schemas skip other versions,
+// so that registering another only requires implementing Actors for it. Code written against Actors alone, such as
+// the message producers, is exercised with another version by the double of package actorstest.
+type Version int
+
+const (
+	// Version0 is the specs-actors v0.9 release line.
+	Version0 Version = 0
+)
+
+// DefaultVersion is the actors version tests run against unless they select another.
+const DefaultVersion = Version0
+
+const (
+	totalFilecoin     = 2_000_000_000
+	filecoinPrecision = 1_000_000_000_000_000_000
+)
+
+// TotalNetworkBalance is the balance of the reward actor at genesis.
+var TotalNetworkBalance = big.Mul(big.NewInt(totalFilecoin), big.NewInt(filecoinPrecision))
+
+// Store is the store actor states are constructed in and loaded from.
+type Store interface {
+	Context() context.Context
+	cbor.IpldStore
+}
+
+// ActorState is the state of an actor installed directly in the state tree.
+type ActorState struct {
+	Addr    address.Address
+	Balance abi.TokenAmount
+	Code    cid.Cid
+	State   cbg.CBORMarshaler
+}
+
+// Codes holds the code CIDs of the builtin actors.
+type Codes struct {
+	System         cid.Cid
+	Init           cid.Cid
+	Cron           cid.Cid
+	Account        cid.Cid
+	Power          cid.Cid
+	Miner          cid.Cid
+	Market         cid.Cid
+	PaymentChannel cid.Cid
+	Multisig       cid.Cid
+	Reward         cid.Cid
+	VerifiedReg    cid.Cid
+}
+
+// MigratedCodes returns the code each actor of `from` is replaced with when the state is migrated to `to`.
+func MigratedCodes(from, to Codes) map[cid.Cid]cid.Cid {
+	return map[cid.Cid]cid.Cid{
+		from.System:         to.System,
+		from.Init:           to.Init,
+		from.Cron:           to.Cron,
+		from.Account:        to.Account,
+		from.Power:          to.Power,
+		from.Miner:          to.Miner,
+		from.Market:         to.Market,
+		from.PaymentChannel: to.PaymentChannel,
+		from.Multisig:       to.Multisig,
+		from.Reward:         to.Reward,
+		from.VerifiedReg:    to.VerifiedReg,
+	}
+}
+
+// MinerParams describes a miner actor installed directly in the state tree.
+type MinerParams struct {
+	// ID addresses of the owner and worker accounts.
+	Owner  address.Address
+	Worker address.Address
+
+	Peer               abi.PeerID
+	SealProofType      abi.RegisteredSealProof
+	ProvingPeriodStart abi.ChainEpoch
+
+	// PreSeals are sectors committed at genesis, holding the deals with the corresponding DealIDs.
+	PreSeals []*types.PreSeal
+	DealIDs  []abi.DealID
+}
+
+// MinerPower is the power of a miner's sectors.
+type MinerPower struct {
+	Raw        abi.StoragePower
+	QualityAdj abi.StoragePower
+}
+
+// MultisigParams describes a multisig actor installed directly in the state tree.
+type MultisigParams struct {
+	// ID addresses of the signers.
+	Signers               []address.Address
+	NumApprovalsThreshold uint64
+
+	InitialBalance abi.TokenAmount
+	StartEpoch     abi.ChainEpoch
+	UnlockDuration abi.ChainEpoch
+}
+
+// MultisigState is the version independent view of a multisig actor's state.
+type MultisigState struct {
+	Signers               []address.Address
+	NumApprovalsThreshold uint64
+	NextTxnID             int64
+
+	InitialBalance abi.TokenAmount
+	StartEpoch     abi.ChainEpoch
+	UnlockDuration abi.ChainEpoch
+
+	PendingTxns cid.Cid
+}
+
+// MultisigTransaction is the version independent view of a transaction pending in a multisig actor.
+type MultisigTransaction struct {
+	To       address.Address
+	Value    abi.TokenAmount
+	Method   abi.MethodNum
+	Params   []byte
+	Approved []address.Address
+}
+
+// RewardState is the version independent view of the reward actor's state.
+type RewardState struct {
+	ThisEpochReward         abi.TokenAmount
+	ExpectedLeadersPerEpoch int64
+}
+
+// Actors constructs and inspects the states of one version of the builtin actors.
+type Actors interface {
+	Version() Version
+	Codes() Codes
+	// MethodNum returns the number of the method named `method`, as by ExportedMethodName, of the actor with code
+	// `code`. Found is false if the actor does not export the method.
+	MethodNum(code cid.Cid, method string) (num abi.MethodNum, found bool)
+
+	// InitializeStore puts the empty structures referenced by newly constructed actor states into `store`.
+	InitializeStore(store Store) error
+	// BuiltinActorsState returns the singleton actors every test starts from.
+	BuiltinActorsState(store Store) ([]ActorState, error)
+	// NewAccountState returns the state of an account actor for the public key address `addr`.
+	NewAccountState(addr address.Address) cbg.CBORMarshaler
+	// NewMinerState returns the state of a miner actor holding the pre-sealed sectors of `params`, and their power.
+	// The sectors are assigned to deadlines, so the miner owes Window PoSts for them once its proving period starts
+	// and its proving cron event is enrolled, see EnrollProvingCron.
+	NewMinerState(store Store, params MinerParams) (cbg.CBORMarshaler, MinerPower, error)
+	// AddMinerToPower returns the power actor state with head `powerHead` after registering a claim of `power` for
+	// `miner` and adding it to the network totals.
+	AddMinerToPower(store Store, powerHead cid.Cid, miner address.Address, power MinerPower) (cbg.CBORMarshaler, error)
+	// EnrollProvingCron returns the power actor state with head `powerHead` after enrolling the cron event `miner`
+	// handles its proving deadlines from, as the miner constructor does for a period starting at `provingPeriodStart`.
+	EnrollProvingCron(store Store, powerHead cid.Cid, miner address.Address, provingPeriodStart abi.ChainEpoch) (cbg.CBORMarshaler, error)
+	// ActivateGenesisDeals returns the market actor state with head `marketHead` after publishing the deals of
+	// `preseals` and activating them at genesis, along with the IDs of the deals. The deals are scheduled for the
+	// market's cron like published deals.
+	ActivateGenesisDeals(store Store, marketHead cid.Cid, preseals []*types.PreSeal) (cbg.CBORMarshaler, []abi.DealID, error)
+	// NewMultisigState returns the state of a multisig actor without pending transactions.
+	NewMultisigState(store Store, params MultisigParams) (cbg.CBORMarshaler, error)
+	// NewVerifiedRegistryState returns the state of the verified registry actor without verifiers, administered by
+	// `rootKey`.
+	NewVerifiedRegistryState(store Store, rootKey address.Address) (cbg.CBORMarshaler, error)
+	// NewRewardState returns the state of the reward actor paying `thisEpochReward` in the first epoch.
+	NewRewardState(thisEpochReward abi.TokenAmount) cbg.CBORMarshaler
+
+	// LoadMultisig decodes the multisig actor state with head `head`.
+	LoadMultisig(store Store, head cid.Cid) (*MultisigState, error)
+	// LoadMultisigTransaction returns the pending transaction `id` of the multisig actor state with head `head`.
+	LoadMultisigTransaction(store Store, head cid.Cid, id int64) (*MultisigTransaction, bool, error)
+	// MultisigLockedBalance returns the balance of a multisig actor in state `st` that cannot be spent at `epoch`.
+	MultisigLockedBalance(st *MultisigState, epoch abi.ChainEpoch) abi.TokenAmount
+	// LoadReward decodes the reward actor state with head `head`.
+	LoadReward(store Store, head cid.Cid) (*RewardState, error)
+}
+
+var registry = make(map[Version]Actors)
+
+// Register makes an actors version available to tests. It panics if the version is already registered.
+func Register(a Actors) {
+	if _, ok := registry[a.Version()]; ok {
+		panic(fmt.Sprintf("actors version %d registered twice", a.Version()))
+	}
+	registry[a.Version()] = a
+}
+
+// Get returns the registered actors of version `v`.
+func Get(v Version) (Actors, error) {
+	a, ok := registry[v]
+	if !ok {
+		return nil, fmt.Errorf("unknown actors version %d", v)
+	}
+	return a, nil
+}
+
+// Versions returns the registered actors versions in ascending order.
+func Versions() []Version {
+	var versions []Version
+	for v := range registry {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
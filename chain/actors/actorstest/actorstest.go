@@ -0,0 +1,90 @@
+// Package actorstest provides a double of a version of the builtin actors, exercising code written against the actors
+// abstraction with a version other than the only one released, as tests cannot tell the double from a real version
+// unless they decode states.
+package actorstest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+)
+
+// MethodOffset is added to the numbers of the methods of the base actors, other than their constructors, so that
+// messages numbered for the base actors are told apart from those numbered for the double.
+const MethodOffset = 100
+
+// Actors is a version of the builtin actors behaving like the base version it embeds, under a version, code CIDs and
+// method numbers of its own. States are those of the base version.
+type Actors struct {
+	actors.Actors
+	version actors.Version
+	codes   actors.Codes
+	// baseCodes maps the codes of the double to those of the base version.
+	baseCodes map[cid.Cid]cid.Cid
+}
+
+var _ actors.Actors = (*Actors)(nil)
+
+// New returns a double of `base` with version `v`. Its code CIDs are named like those of the builtin actors, with
+// the version in place of the release, e.g. fil/test1/account.
+func New(base actors.Actors, v actors.Version) *Actors {
+	a := &Actors{Actors: base, version: v, baseCodes: make(map[cid.Cid]cid.Cid)}
+	codes := reflect.ValueOf(&a.codes).Elem()
+	baseCodes := reflect.ValueOf(base.Codes())
+	for i := 0; i < codes.NumField(); i++ {
+		code := mustIdentityCid(fmt.Sprintf("fil/test%d/%s", v, codes.Type().Field(i).Name))
+		codes.Field(i).Set(reflect.ValueOf(code))
+		a.baseCodes[code] = baseCodes.Field(i).Interface().(cid.Cid)
+	}
+	return a
+}
+
+func (a *Actors) Version() actors.Version {
+	return a.version
+}
+
+func (a *Actors) Codes() actors.Codes {
+	return a.codes
+}
+
+func (a *Actors) MethodNum(code cid.Cid, method string) (abi.MethodNum, bool) {
+	baseCode, ok := a.baseCodes[code]
+	if !ok {
+		return 0, false
+	}
+	num, ok := a.Actors.MethodNum(baseCode, method)
+	if !ok || num == builtin.MethodConstructor {
+		return num, ok
+	}
+	return num + MethodOffset, true
+}
+
+// BuiltinActorsState returns the builtin actors of the base version, with the codes of the double.
+func (a *Actors) BuiltinActorsState(store actors.Store) ([]actors.ActorState, error) {
+	states, err := a.Actors.BuiltinActorsState(store)
+	if err != nil {
+		return nil, err
+	}
+	toDouble := make(map[cid.Cid]cid.Cid, len(a.baseCodes))
+	for code, baseCode := range a.baseCodes {
+		toDouble[baseCode] = code
+	}
+	for i := range states {
+		states[i].Code = toDouble[states[i].Code]
+	}
+	return states, nil
+}
+
+func mustIdentityCid(name string) cid.Cid {
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.IDENTITY, MhLength: -1}.Sum([]byte(name))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
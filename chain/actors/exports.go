@@ -0,0 +1,35 @@
+package actors
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+)
+
+// ExportedMethodName returns the name of a method exported by a builtin actor, given its entry in the actor's
+// exports.
+func ExportedMethodName(export interface{}) string {
+	name := runtime.FuncForPC(reflect.ValueOf(export).Pointer()).Name()
+	// method values are named after the method with a -fm suffix.
+	name = strings.TrimSuffix(name, "-fm")
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// exportedMethods maps the code of each actor of `exports` to the numbers of the methods it exports, keyed by
+// ExportedMethodName. An export's index in the exports of its actor is its method number.
+func exportedMethods(exports map[cid.Cid][]interface{}) map[cid.Cid]map[string]abi.MethodNum {
+	methods := make(map[cid.Cid]map[string]abi.MethodNum, len(exports))
+	for code, actorExports := range exports {
+		byName := make(map[string]abi.MethodNum, len(actorExports))
+		for num, export := range actorExports {
+			if export != nil {
+				byName[ExportedMethodName(export)] = abi.MethodNum(num)
+			}
+		}
+		methods[code] = byName
+	}
+	return methods
+}
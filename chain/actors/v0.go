@@ -0,0 +1,496 @@
+package actors
+
+import (
+	"bytes"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtin0 "github.com/filecoin-project/specs-actors/actors/builtin"
+	account0 "github.com/filecoin-project/specs-actors/actors/builtin/account"
+	cron0 "github.com/filecoin-project/specs-actors/actors/builtin/cron"
+	init0 "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	market0 "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	miner0 "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	multisig0 "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	paych0 "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	power0 "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	reward0 "github.com/filecoin-project/specs-actors/actors/builtin/reward"
+	system0 "github.com/filecoin-project/specs-actors/actors/builtin/system"
+	verifreg0 "github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
+	adt0 "github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+func init() {
+	Register(V0)
+}
+
+// V0 is the specs-actors v0.9 release line.
+var V0 Actors = actorsV0{}
+
+type actorsV0 struct{}
+
+var _ Actors = actorsV0{}
+
+// the empty structures referenced by newly constructed v0 actor states.
+type emptyRootsV0 struct {
+	array, mapp, multimap, deadlines, vestingFunds, bitfield cid.Cid
+}
+
+func (actorsV0) emptyRoots(store Store) (*emptyRootsV0, error) {
+	var roots emptyRootsV0
+	var err error
+	if roots.array, err = adt0.MakeEmptyArray(store).Root(); err != nil {
+		return nil, err
+	}
+	if roots.mapp, err = adt0.MakeEmptyMap(store).Root(); err != nil {
+		return nil, err
+	}
+	if roots.multimap, err = adt0.MakeEmptyMultimap(store).Root(); err != nil {
+		return nil, err
+	}
+	if roots.deadlines, err = store.Put(store.Context(), miner0.ConstructDeadline(roots.array)); err != nil {
+		return nil, err
+	}
+	if roots.vestingFunds, err = store.Put(store.Context(), miner0.ConstructVestingFunds()); err != nil {
+		return nil, err
+	}
+	if roots.bitfield, err = store.Put(store.Context(), bitfield.New()); err != nil {
+		return nil, err
+	}
+	return &roots, nil
+}
+
+func (actorsV0) Version() Version {
+	return Version0
+}
+
+func (actorsV0) Codes() Codes {
+	return Codes{
+		System:         builtin0.SystemActorCodeID,
+		Init:           builtin0.InitActorCodeID,
+		Cron:           builtin0.CronActorCodeID,
+		Account:        builtin0.AccountActorCodeID,
+		Power:          builtin0.StoragePowerActorCodeID,
+		Miner:          builtin0.StorageMinerActorCodeID,
+		Market:         builtin0.StorageMarketActorCodeID,
+		PaymentChannel: builtin0.PaymentChannelActorCodeID,
+		Multisig:       builtin0.MultisigActorCodeID,
+		Reward:         builtin0.RewardActorCodeID,
+		VerifiedReg:    builtin0.VerifiedRegistryActorCodeID,
+	}
+}
+
+// methodsV0 holds the numbers of the methods of the v0 builtin actors.
+var methodsV0 = exportedMethods(map[cid.Cid][]interface{}{
+	builtin0.SystemActorCodeID:           system0.Actor{}.Exports(),
+	builtin0.InitActorCodeID:             init0.Actor{}.Exports(),
+	builtin0.CronActorCodeID:             cron0.Actor{}.Exports(),
+	builtin0.AccountActorCodeID:          account0.Actor{}.Exports(),
+	builtin0.StoragePowerActorCodeID:     power0.Actor{}.Exports(),
+	builtin0.StorageMinerActorCodeID:     miner0.Actor{}.Exports(),
+	builtin0.StorageMarketActorCodeID:    market0.Actor{}.Exports(),
+	builtin0.PaymentChannelActorCodeID:   paych0.Actor{}.Exports(),
+	builtin0.MultisigActorCodeID:         multisig0.Actor{}.Exports(),
+	builtin0.RewardActorCodeID:           reward0.Actor{}.Exports(),
+	builtin0.VerifiedRegistryActorCodeID: verifreg0.Actor{}.Exports(),
+})
+
+func (actorsV0) MethodNum(code cid.Cid, method string) (abi.MethodNum, bool) {
+	num, ok := methodsV0[code][method]
+	return num, ok
+}
+
+func (a actorsV0) InitializeStore(store Store) error {
+	_, err := a.emptyRoots(store)
+	return err
+}
+
+func (a actorsV0) BuiltinActorsState(store Store) ([]ActorState, error) {
+	roots, err := a.emptyRoots(store)
+	if err != nil {
+		return nil, err
+	}
+
+	return []ActorState{
+		{
+			Addr:    builtin0.InitActorAddr,
+			Balance: big.Zero(),
+			Code:    builtin0.InitActorCodeID,
+			State:   init0.ConstructState(roots.mapp, "chain-validation"),
+		},
+		{
+			Addr:    builtin0.RewardActorAddr,
+			Balance: TotalNetworkBalance,
+			Code:    builtin0.RewardActorCodeID,
+			State:   a.NewRewardState(big.NewInt(1e17)),
+		},
+		{
+			Addr:    builtin0.BurntFundsActorAddr,
+			Balance: big.Zero(),
+			Code:    builtin0.AccountActorCodeID,
+			State:   &account0.State{Address: builtin0.BurntFundsActorAddr},
+		},
+		{
+			Addr:    builtin0.StoragePowerActorAddr,
+			Balance: big.Zero(),
+			Code:    builtin0.StoragePowerActorCodeID,
+			State:   power0.ConstructState(roots.mapp, roots.multimap),
+		},
+		{
+			Addr:    builtin0.StorageMarketActorAddr,
+			Balance: big.Zero(),
+			Code:    builtin0.StorageMarketActorCodeID,
+			State: &market0.State{
+				Proposals:        roots.array,
+				States:           roots.array,
+				PendingProposals: roots.mapp,
+				EscrowTable:      roots.mapp,
+				LockedTable:      roots.mapp,
+				NextID:           abi.DealID(0),
+				DealOpsByEpoch:   roots.multimap,
+				LastCron:         0,
+			},
+		},
+		{
+			Addr:    builtin0.SystemActorAddr,
+			Balance: big.Zero(),
+			Code:    builtin0.SystemActorCodeID,
+			State:   &system0.State{},
+		},
+		{
+			Addr:    builtin0.CronActorAddr,
+			Balance: big.Zero(),
+			Code:    builtin0.CronActorCodeID,
+			State: &cron0.State{Entries: []cron0.Entry{
+				{
+					Receiver:  builtin0.StoragePowerActorAddr,
+					MethodNum: builtin0.MethodsPower.OnEpochTickEnd,
+				},
+			}},
+		},
+	}, nil
+}
+
+func (actorsV0) NewAccountState(addr address.Address) cbg.CBORMarshaler {
+	return &account0.State{Address: addr}
+}
+
+func (a actorsV0) NewMinerState(store Store, params MinerParams) (cbg.CBORMarshaler, MinerPower, error) {
+	power := MinerPower{Raw: big.Zero(), QualityAdj: big.Zero()}
+	if len(params.PreSeals) != len(params.DealIDs) {
+		return nil, power, xerrors.Errorf("%d pre-sealed sectors but %d deal IDs", len(params.PreSeals), len(params.DealIDs))
+	}
+	roots, err := a.emptyRoots(store)
+	if err != nil {
+		return nil, power, err
+	}
+	ss, err := params.SealProofType.SectorSize()
+	if err != nil {
+		return nil, power, err
+	}
+	ps, err := builtin0.SealProofWindowPoStPartitionSectors(params.SealProofType)
+	if err != nil {
+		return nil, power, err
+	}
+	mi := &miner0.MinerInfo{
+		Owner:                      params.Owner,
+		Worker:                     params.Worker,
+		PendingWorkerKey:           nil,
+		PeerId:                     params.Peer,
+		Multiaddrs:                 nil,
+		SealProofType:              params.SealProofType,
+		SectorSize:                 ss,
+		WindowPoStPartitionSectors: ps,
+	}
+	mc, err := store.Put(store.Context(), mi)
+	if err != nil {
+		return nil, power, err
+	}
+	st, err := miner0.ConstructState(mc, params.ProvingPeriodStart, roots.bitfield, roots.array, roots.mapp, roots.deadlines, roots.vestingFunds)
+	if err != nil || len(params.PreSeals) == 0 {
+		return st, power, err
+	}
+
+	sectors := make([]*miner0.SectorOnChainInfo, len(params.PreSeals))
+	allocated := make([]uint64, len(params.PreSeals))
+	for i, preseal := range params.PreSeals {
+		if preseal.ProofType != params.SealProofType {
+			return nil, power, xerrors.Errorf("sector %d has seal proof %d, miner has %d", preseal.SectorID, preseal.ProofType, params.SealProofType)
+		}
+		duration := preseal.Deal.EndEpoch
+		dealWeight := big.Mul(big.NewIntUnsigned(uint64(preseal.Deal.PieceSize)), big.NewInt(int64(duration)))
+		sectors[i] = &miner0.SectorOnChainInfo{
+			SectorNumber:          preseal.SectorID,
+			SealProof:             preseal.ProofType,
+			SealedCID:             preseal.CommR,
+			DealIDs:               []abi.DealID{params.DealIDs[i]},
+			Activation:            0,
+			Expiration:            preseal.Deal.EndEpoch,
+			DealWeight:            dealWeight,
+			VerifiedDealWeight:    big.Zero(),
+			InitialPledge:         big.Zero(),
+			ExpectedDayReward:     big.Zero(),
+			ExpectedStoragePledge: big.Zero(),
+		}
+		allocated[i] = uint64(preseal.SectorID)
+	}
+	if err := st.PutSectors(store, sectors...); err != nil {
+		return nil, power, err
+	}
+	if st.AllocatedSectors, err = store.Put(store.Context(), bitfield.NewFromSet(allocated)); err != nil {
+		return nil, power, err
+	}
+
+	// the empty deadlines root holds a single deadline, so miners with sectors get a table of deadlines of their own
+	// to assign them to, as ConfirmSectorProofsValid does.
+	if st.Deadlines, err = store.Put(store.Context(), miner0.ConstructDeadlines(roots.deadlines)); err != nil {
+		return nil, power, err
+	}
+	pp, err := st.AssignSectorsToDeadlines(store, 0, sectors, ps, ss)
+	if err != nil {
+		return nil, power, err
+	}
+	return st, MinerPower{Raw: pp.Raw, QualityAdj: pp.QA}, nil
+}
+
+func (actorsV0) AddMinerToPower(store Store, powerHead cid.Cid, miner address.Address, power MinerPower) (cbg.CBORMarshaler, error) {
+	var st power0.State
+	if err := store.Get(store.Context(), powerHead, &st); err != nil {
+		return nil, err
+	}
+	claims, err := adt0.AsMap(store, st.Claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := claims.Put(adt0.AddrKey(miner), &power0.Claim{
+		RawBytePower:    power.Raw,
+		QualityAdjPower: power.QualityAdj,
+	}); err != nil {
+		return nil, err
+	}
+	if st.Claims, err = claims.Root(); err != nil {
+		return nil, err
+	}
+	st.MinerCount += 1
+
+	if power.Raw.GreaterThan(big.Zero()) {
+		st.TotalRawBytePower = big.Add(st.TotalRawBytePower, power.Raw)
+		st.TotalBytesCommitted = big.Add(st.TotalBytesCommitted, power.Raw)
+		st.TotalQualityAdjPower = big.Add(st.TotalQualityAdjPower, power.QualityAdj)
+		st.TotalQABytesCommitted = big.Add(st.TotalQABytesCommitted, power.QualityAdj)
+		st.ThisEpochRawBytePower = st.TotalRawBytePower
+		st.ThisEpochQualityAdjPower = st.TotalQualityAdjPower
+		if power.QualityAdj.GreaterThanEqual(power0.ConsensusMinerMinPower) {
+			st.MinerAboveMinPowerCount += 1
+		}
+	}
+	return &st, nil
+}
+
+func (actorsV0) EnrollProvingCron(store Store, powerHead cid.Cid, miner address.Address, provingPeriodStart abi.ChainEpoch) (cbg.CBORMarshaler, error) {
+	var st power0.State
+	if err := store.Get(store.Context(), powerHead, &st); err != nil {
+		return nil, err
+	}
+	payload := new(bytes.Buffer)
+	if err := (&miner0.CronEventPayload{EventType: miner0.CronEventProvingDeadline}).MarshalCBOR(payload); err != nil {
+		return nil, err
+	}
+	events, err := adt0.AsMultimap(store, st.CronEventQueue)
+	if err != nil {
+		return nil, err
+	}
+	// the miner constructor enrolls the event at the epoch before the proving period starts.
+	epoch := provingPeriodStart - 1
+	if err := events.Add(abi.IntKey(int64(epoch)), &power0.CronEvent{
+		MinerAddr:       miner,
+		CallbackPayload: payload.Bytes(),
+	}); err != nil {
+		return nil, err
+	}
+	if st.CronEventQueue, err = events.Root(); err != nil {
+		return nil, err
+	}
+	if epoch < st.FirstCronEpoch {
+		st.FirstCronEpoch = epoch
+	}
+	return &st, nil
+}
+
+func (actorsV0) ActivateGenesisDeals(store Store, marketHead cid.Cid, preseals []*types.PreSeal) (cbg.CBORMarshaler, []abi.DealID, error) {
+	var st market0.State
+	if err := store.Get(store.Context(), marketHead, &st); err != nil {
+		return nil, nil, err
+	}
+	proposals, err := adt0.AsArray(store, st.Proposals)
+	if err != nil {
+		return nil, nil, err
+	}
+	states, err := adt0.AsArray(store, st.States)
+	if err != nil {
+		return nil, nil, err
+	}
+	pending, err := adt0.AsMap(store, st.PendingProposals)
+	if err != nil {
+		return nil, nil, err
+	}
+	ops, err := market0.AsSetMultimap(store, st.DealOpsByEpoch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make([]abi.DealID, len(preseals))
+	for i, preseal := range preseals {
+		id := st.NextID
+		st.NextID++
+		deal := preseal.Deal
+		if err := proposals.Set(uint64(id), &deal); err != nil {
+			return nil, nil, err
+		}
+		// PublishStorageDeals leaves the proposal pending until the market's cron first processes the deal, at its
+		// start epoch or, for deals starting at genesis, the first epoch the cron has yet to process.
+		pcid, err := deal.Cid()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := pending.Put(abi.CidKey(pcid), &deal); err != nil {
+			return nil, nil, err
+		}
+		opEpoch := deal.StartEpoch
+		if opEpoch <= st.LastCron {
+			opEpoch = st.LastCron + 1
+		}
+		if err := ops.Put(opEpoch, id); err != nil {
+			return nil, nil, err
+		}
+		if err := states.Set(uint64(id), &market0.DealState{
+			SectorStartEpoch: 0,
+			LastUpdatedEpoch: -1,
+			SlashEpoch:       -1,
+		}); err != nil {
+			return nil, nil, err
+		}
+		ids[i] = id
+	}
+
+	if st.Proposals, err = proposals.Root(); err != nil {
+		return nil, nil, err
+	}
+	if st.States, err = states.Root(); err != nil {
+		return nil, nil, err
+	}
+	if st.PendingProposals, err = pending.Root(); err != nil {
+		return nil, nil, err
+	}
+	if st.DealOpsByEpoch, err = ops.Root(); err != nil {
+		return nil, nil, err
+	}
+	return &st, ids, nil
+}
+
+func (a actorsV0) NewMultisigState(store Store, params MultisigParams) (cbg.CBORMarshaler, error) {
+	roots, err := a.emptyRoots(store)
+	if err != nil {
+		return nil, err
+	}
+	st := &multisig0.State{
+		Signers:               params.Signers,
+		NumApprovalsThreshold: params.NumApprovalsThreshold,
+		NextTxnID:             0,
+		InitialBalance:        big.Zero(),
+		StartEpoch:            params.StartEpoch,
+		UnlockDuration:        0,
+		PendingTxns:           roots.mapp,
+	}
+	if params.UnlockDuration > 0 {
+		st.InitialBalance = params.InitialBalance
+		st.UnlockDuration = params.UnlockDuration
+	}
+	return st, nil
+}
+
+func (a actorsV0) NewVerifiedRegistryState(store Store, rootKey address.Address) (cbg.CBORMarshaler, error) {
+	roots, err := a.emptyRoots(store)
+	if err != nil {
+		return nil, err
+	}
+	return verifreg0.ConstructState(roots.mapp, rootKey), nil
+}
+
+func (actorsV0) NewRewardState(thisEpochReward abi.TokenAmount) cbg.CBORMarshaler {
+	st := reward0.ConstructState(big.Zero())
+	st.ThisEpochReward = thisEpochReward
+	return st
+}
+
+func (actorsV0) LoadMultisig(store Store, head cid.Cid) (*MultisigState, error) {
+	var st multisig0.State
+	if err := store.Get(store.Context(), head, &st); err != nil {
+		return nil, xerrors.Errorf("loading multisig state %s: %w", head, err)
+	}
+	return &MultisigState{
+		Signers:               st.Signers,
+		NumApprovalsThreshold: st.NumApprovalsThreshold,
+		NextTxnID:             int64(st.NextTxnID),
+		InitialBalance:        st.InitialBalance,
+		StartEpoch:            st.StartEpoch,
+		UnlockDuration:        st.UnlockDuration,
+		PendingTxns:           st.PendingTxns,
+	}, nil
+}
+
+func (actorsV0) LoadMultisigTransaction(store Store, head cid.Cid, id int64) (*MultisigTransaction, bool, error) {
+	var st multisig0.State
+	if err := store.Get(store.Context(), head, &st); err != nil {
+		return nil, false, xerrors.Errorf("loading multisig state %s: %w", head, err)
+	}
+	txns, err := adt0.AsMap(store, st.PendingTxns)
+	if err != nil {
+		return nil, false, err
+	}
+	var txn multisig0.Transaction
+	found, err := txns.Get(multisig0.TxnID(id), &txn)
+	if err != nil || !found {
+		return nil, found, err
+	}
+	return &MultisigTransaction{
+		To:       txn.To,
+		Value:    txn.Value,
+		Method:   txn.Method,
+		Params:   txn.Params,
+		Approved: txn.Approved,
+	}, true, nil
+}
+
+// MultisigLockedBalance locks the initial balance divided by the unlock duration, rounded down, for each epoch of the
+// duration remaining. Before the start epoch the whole initial balance is locked: the actor never asks for the amount
+// locked at such epochs, and extrapolating the vesting schedule backwards would lock more than was ever vested.
+func (actorsV0) MultisigLockedBalance(st *MultisigState, epoch abi.ChainEpoch) abi.TokenAmount {
+	if epoch < st.StartEpoch {
+		return st.InitialBalance
+	}
+	ms := multisig0.State{
+		InitialBalance: st.InitialBalance,
+		StartEpoch:     st.StartEpoch,
+		UnlockDuration: st.UnlockDuration,
+	}
+	return ms.AmountLocked(epoch - st.StartEpoch)
+}
+
+func (actorsV0) LoadReward(store Store, head cid.Cid) (*RewardState, error) {
+	var st reward0.State
+	if err := store.Get(store.Context(), head, &st); err != nil {
+		return nil, xerrors.Errorf("loading reward state %s: %w", head, err)
+	}
+	return &RewardState{
+		ThisEpochReward:         st.ThisEpochReward,
+		ExpectedLeadersPerEpoch: builtin0.ExpectedLeadersPerEpoch,
+	}, nil
+}
@@ -1,23 +1,33 @@
 package chain
 
 import (
+	"fmt"
+
 	address "github.com/filecoin-project/go-address"
 	abi_spec "github.com/filecoin-project/go-state-types/abi"
 	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/ipfs/go-cid"
 
+	"github.com/filecoin-project/chain-validation/chain/actors"
 	"github.com/filecoin-project/chain-validation/chain/types"
 )
 
+//go:generate go run github.com/filecoin-project/chain-validation/gen
+
 // The created messages are retained for subsequent export or evaluation in a VM.
 type MessageProducer struct {
 	defaults msgOpts // Note non-pointer reference.
 
+	// version of the actors messages are produced for
+	av actors.Actors
+
 	messages []*types.Message
 }
 
-// NewMessageProducer creates a new message producer, delegating message creation to `factory`.
-func NewMessageProducer(defaultGasFeeCap abi_spec.TokenAmount, defaultGasPremium abi_spec.TokenAmount, defaultGasLimit int64) *MessageProducer {
+// NewMessageProducer creates a new message producer for version `av` of the actors.
+func NewMessageProducer(av actors.Actors, defaultGasFeeCap abi_spec.TokenAmount, defaultGasPremium abi_spec.TokenAmount, defaultGasLimit int64) *MessageProducer {
 	return &MessageProducer{
+		av: av,
 		defaults: msgOpts{
 			value:      big_spec.Zero(),
 			gasLimit:   defaultGasLimit,
@@ -27,6 +37,21 @@ func NewMessageProducer(defaultGasFeeCap abi_spec.TokenAmount, defaultGasPremium
 	}
 }
 
+// SetActors switches the producer to version `av` of the actors, e.g. once the state is migrated to it.
+func (mp *MessageProducer) SetActors(av actors.Actors) {
+	mp.av = av
+}
+
+// methodNum returns the number of method `method` of the actor with code `code` in the actors version of the producer.
+// It panics if the actor does not export the method, as messages calling it cannot be produced for the version.
+func (mp *MessageProducer) methodNum(code cid.Cid, method string) abi_spec.MethodNum {
+	num, ok := mp.av.MethodNum(code, method)
+	if !ok {
+		panic(fmt.Sprintf("actor %s of actors version %d does not export %s", code, mp.av.Version(), method))
+	}
+	return num
+}
+
 // Messages returns a slice containing all messages created by the producer.
 func (mp *MessageProducer) Messages() []*types.Message {
 	return mp.messages
@@ -0,0 +1,56 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/chain/actors/actorstest"
+)
+
+func TestProducersNumberMethodsForActorsVersion(t *testing.T) {
+	double := actorstest.New(actors.V0, actors.Version0+1)
+	from, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	to, err := address.NewIDAddress(101)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		av actors.Actors
+		// method numbers expected for MultisigPropose and for the InitExec of CreateMultisigActor
+		propose, exec uint64
+		// code expected in the exec params of CreateMultisigActor
+		multisig actors.Codes
+	}{
+		{
+			av:       actors.V0,
+			propose:  uint64(builtin_spec.MethodsMultisig.Propose),
+			exec:     uint64(builtin_spec.MethodsInit.Exec),
+			multisig: actors.V0.Codes(),
+		},
+		{
+			av:       double,
+			propose:  uint64(builtin_spec.MethodsMultisig.Propose) + actorstest.MethodOffset,
+			exec:     uint64(builtin_spec.MethodsInit.Exec) + actorstest.MethodOffset,
+			multisig: double.Codes(),
+		},
+	} {
+		mp := NewMessageProducer(tc.av, big_spec.Zero(), big_spec.Zero(), 0)
+
+		msg := mp.MultisigPropose(from, to, &multisig_spec.ProposeParams{To: to, Value: big_spec.Zero()})
+		assert.EqualValues(t, tc.propose, msg.Method, "actors version %d", tc.av.Version())
+
+		msg = mp.CreateMultisigActor(from, []address.Address{from}, 0, 1)
+		assert.EqualValues(t, tc.exec, msg.Method, "actors version %d", tc.av.Version())
+		var exec init_spec.ExecParams
+		require.NoError(t, Deserialize(msg.Params, &exec))
+		assert.Equal(t, tc.multisig.Multisig, exec.CodeCID, "actors version %d", tc.av.Version())
+	}
+}
@@ -22,7 +22,7 @@ func (mp *MessageProducer) Transfer(from, to address.Address, opts ...MsgOpt) *t
 
 func (mp *MessageProducer) CreatePaymentChannelActor(from, to address.Address, opts ...MsgOpt) *types.Message {
 	return mp.InitExec(from, builtin_spec.InitActorAddr, &init_spec.ExecParams{
-		CodeCID: builtin_spec.PaymentChannelActorCodeID,
+		CodeCID: mp.av.Codes().PaymentChannel,
 		ConstructorParams: MustSerialize(&paych_spec.ConstructorParams{
 			From: from,
 			To:   to,
@@ -32,7 +32,7 @@ func (mp *MessageProducer) CreatePaymentChannelActor(from, to address.Address, o
 
 func (mp *MessageProducer) CreateMultisigActor(from address.Address, signers []address.Address, unlockDuration abi_spec.ChainEpoch, numApprovals uint64, opts ...MsgOpt) *types.Message {
 	return mp.InitExec(from, builtin_spec.InitActorAddr, &init_spec.ExecParams{
-		CodeCID: builtin_spec.MultisigActorCodeID,
+		CodeCID: mp.av.Codes().Multisig,
 		ConstructorParams: MustSerialize(&multisig_spec.ConstructorParams{
 			Signers:               signers,
 			NumApprovalsThreshold: numApprovals,
@@ -3,15 +3,20 @@ package types
 import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
 )
 
+// DefaultNetworkVersion is the network version messages are executed under unless a test selects another.
+const DefaultNetworkVersion = network.Version0
+
 // ExecutionContext provides the context for execution of a message.
 type ExecutionContext struct {
-	Epoch abi.ChainEpoch  // The epoch number ("height") during which a message is executed.
-	Miner address.Address // The miner actor which earns gas fees from message execution.
+	Epoch          abi.ChainEpoch  // The epoch number ("height") during which a message is executed.
+	Miner          address.Address // The miner actor which earns gas fees from message execution.
+	NetworkVersion network.Version // The network version whose rules a message is executed under.
 }
 
-// NewExecutionContext builds a new execution context.
+// NewExecutionContext builds a new execution context using the default network version.
 func NewExecutionContext(epoch int64, miner address.Address) *ExecutionContext {
-	return &ExecutionContext{abi.ChainEpoch(epoch), miner}
+	return &ExecutionContext{abi.ChainEpoch(epoch), miner, DefaultNetworkVersion}
 }
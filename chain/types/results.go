@@ -1,15 +1,14 @@
 package types
 
 import (
-	"fmt"
-
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/ipfs/go-cid"
 )
 
+// Trackable is the result of applying messages to a state, recorded as expectations by the tracker.
 type Trackable interface {
-	GoSyntax() string
-	GoContainer() string
+	StateRoot() cid.Cid
 }
 
 var _ Trackable = (*ApplyMessageResult)(nil)
@@ -23,14 +22,6 @@ type ApplyMessageResult struct {
 	Root    string
 }
 
-func (mr ApplyMessageResult) GoSyntax() string {
-	return fmt.Sprintf("types.ApplyMessageResult{Receipt: %#v, Penalty: abi.NewTokenAmount(%d), Reward: abi.NewTokenAmount(%d), Root: \"%s\"}", mr.Receipt, mr.Penalty, mr.Reward, mr.Root)
-}
-
-func (mr ApplyMessageResult) GoContainer() string {
-	return "[]types.ApplyMessageResult"
-}
-
 func (mr ApplyMessageResult) StateRoot() cid.Cid {
 	root, err := cid.Decode(mr.Root)
 	if err != nil {
@@ -45,15 +36,17 @@ func (mr ApplyMessageResult) GasUsed() GasUnits {
 
 type ApplyTipSetResult struct {
 	Receipts []MessageReceipt
-	Root     string
-}
-
-func (tr ApplyTipSetResult) GoSyntax() string {
-	return fmt.Sprintf("%#v", tr)
+	// Rewards holds the totals paid to the miner of each block applied, in block order.
+	Rewards []BlockReward `json:",omitempty"`
+	Root    string
 }
 
-func (tr ApplyTipSetResult) GoContainer() string {
-	return "[]types.ApplyTipSetResult"
+// BlockReward is the total gas reward paid to, and penalty charged to, the miner of a block for the messages it
+// included in a tipset.
+type BlockReward struct {
+	Miner   address.Address
+	Penalty abi.TokenAmount
+	Reward  abi.TokenAmount
 }
 
 func (tr ApplyTipSetResult) StateRoot() cid.Cid {
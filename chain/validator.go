@@ -1,7 +1,9 @@
 package chain
 
 import (
-	"github.com/filecoin-project/go-state-types/abi"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/network"
 
 	"github.com/filecoin-project/chain-validation/chain/types"
 	"github.com/filecoin-project/chain-validation/state"
@@ -10,22 +12,74 @@ import (
 // validator arranges the execution of a sequence of messages, returning the resulting receipts and state.
 type Validator struct {
 	applier state.Applier
+
+	// network version of the genesis state and upgrades following it, set if the chain has an upgrade schedule
+	genesis  network.Version
+	schedule state.UpgradeSchedule
 }
 
 // NewValidator builds a new validator.
 func NewValidator(executor state.Applier) *Validator {
-	return &Validator{executor}
+	return &Validator{applier: executor}
 }
 
 // ApplyMessages applies a message to a state
-func (v *Validator) ApplyMessage(epoch abi.ChainEpoch, message *types.Message) (types.ApplyMessageResult, error) {
-	return v.applier.ApplyMessage(epoch, message)
+func (v *Validator) ApplyMessage(ctx *types.ExecutionContext, message *types.Message) (types.ApplyMessageResult, error) {
+	if err := v.updateNetworkVersion(ctx); err != nil {
+		return types.ApplyMessageResult{}, err
+	}
+	return v.applier.ApplyMessage(ctx.Epoch, message)
+}
+
+func (v *Validator) ApplySignedMessage(ctx *types.ExecutionContext, message *types.SignedMessage) (types.ApplyMessageResult, error) {
+	if err := v.updateNetworkVersion(ctx); err != nil {
+		return types.ApplyMessageResult{}, err
+	}
+	return v.applier.ApplySignedMessage(ctx.Epoch, message)
+}
+
+func (v *Validator) ApplyTipSetMessages(ctx *types.ExecutionContext, blocks []types.BlockMessagesInfo, rnd state.RandomnessSource) (types.ApplyTipSetResult, error) {
+	if err := v.updateNetworkVersion(ctx); err != nil {
+		return types.ApplyTipSetResult{}, err
+	}
+	return v.applier.ApplyTipSetMessages(ctx.Epoch, blocks, rnd)
+}
+
+// SetNetworkVersion passes the network version to the applier if it supports more than one. Appliers that don't
+// support it only execute messages under the default network version.
+func (v *Validator) SetNetworkVersion(nv network.Version) error {
+	if va, ok := v.applier.(state.VersionedApplier); ok {
+		return va.SetNetworkVersion(nv)
+	}
+	if nv != types.DefaultNetworkVersion {
+		return xerrors.Errorf("applier does not support network version %d: %w", nv, state.ErrUnsupportedNetworkVersion)
+	}
+	return nil
 }
 
-func (v *Validator) ApplySignedMessage(epoch abi.ChainEpoch, message *types.SignedMessage) (types.ApplyMessageResult, error) {
-	return v.applier.ApplySignedMessage(epoch, message)
+// SetUpgradeSchedule passes the upgrades of a chain whose genesis has network version `genesis` to the applier. From
+// then on the network version of each execution context follows the schedule.
+func (v *Validator) SetUpgradeSchedule(genesis network.Version, schedule state.UpgradeSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	ua, ok := v.applier.(state.UpgradeApplier)
+	if !ok {
+		return xerrors.Errorf("applier does not support network upgrades: %w", state.ErrUnsupportedNetworkVersion)
+	}
+	if err := ua.SetUpgradeSchedule(genesis, schedule); err != nil {
+		return err
+	}
+	v.genesis = genesis
+	v.schedule = schedule
+	return nil
 }
 
-func (v *Validator) ApplyTipSetMessages(epoch abi.ChainEpoch, blocks []types.BlockMessagesInfo, rnd state.RandomnessSource) (types.ApplyTipSetResult, error) {
-	return v.applier.ApplyTipSetMessages(epoch, blocks, rnd)
+// updateNetworkVersion derives the network version of `ctx` from its epoch if the chain has an upgrade schedule, and
+// passes it to the applier.
+func (v *Validator) updateNetworkVersion(ctx *types.ExecutionContext) error {
+	if v.schedule != nil {
+		ctx.NetworkVersion = v.schedule.NetworkVersion(v.genesis, ctx.Epoch)
+	}
+	return v.SetNetworkVersion(ctx.NetworkVersion)
 }
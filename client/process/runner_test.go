@@ -83,6 +83,20 @@ func TestChainValidationTipSetSuite(t *testing.T) {
 	}
 }
 
+func TestChainValidationUpgradeSuite(t *testing.T) {
+	cfg := client.Config{
+		Host:    host,
+		Port:    port,
+		Timeout: timeout,
+	}
+	handler := services.NewServiceHandler(client.NewRpcClient(cfg))
+	for _, testCase := range suites.UpgradeTestCases() {
+		t.Run(caseName(testCase), func(t *testing.T) {
+			testCase(t, handler)
+		})
+	}
+}
+
 func caseName(testCase suites.TestCase) string {
 	fqName := runtime.FuncForPC(reflect.ValueOf(testCase).Pointer()).Name()
 	toks := strings.Split(fqName, ".")
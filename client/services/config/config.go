@@ -19,6 +19,11 @@ type ConfigReply struct {
 	CheckExitCode    bool `json:"checkExitCode"`
 	CheckReturnValue bool `json:"checkReturnValue"`
 	CheckStateRoot   bool `json:"checkStateRoot"`
+	CheckRewards     bool `json:"checkRewards"`
+	CheckInvariants  bool `json:"checkInvariants"`
+	ToleratePanics   bool `json:"toleratePanics"`
+
+	Profiles []string `json:"profiles"`
 
 	TestSuite []string `json:"testSuite"`
 }
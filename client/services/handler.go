@@ -89,6 +89,22 @@ func (c configWrapper) ValidateStateRoot() bool {
 	return c.cfg.CheckStateRoot
 }
 
+func (c configWrapper) ValidateRewardsAndPenalties() bool {
+	return c.cfg.CheckRewards
+}
+
+func (c configWrapper) ValidateInvariants() bool {
+	return c.cfg.CheckInvariants
+}
+
+func (c configWrapper) TolerateImplementationPanics() bool {
+	return c.cfg.ToleratePanics
+}
+
+func (c configWrapper) ExpectationProfiles() []string {
+	return c.cfg.Profiles
+}
+
 //
 // Impl VMWrapper interface
 //
@@ -107,6 +123,10 @@ func (s *ServiceHandler) Root() cid.Cid {
 	return root
 }
 
+func (s *ServiceHandler) SetRoot(root cid.Cid) error {
+	return s.vm.SetRoot(root)
+}
+
 func (s *ServiceHandler) StoreGet(key cid.Cid, out runtime.CBORUnmarshaler) error {
 	return s.vm.StoreGet(key, out)
 }
@@ -180,6 +200,7 @@ func (s *ServiceHandler) ApplyTipSetMessages(epoch abi.ChainEpoch, blocks []type
 	}
 	return types.ApplyTipSetResult{
 		Receipts: reply.Receipts,
+		Rewards:  reply.Rewards,
 		Root:     reply.Root.String(),
 	}, nil
 }
@@ -23,6 +23,7 @@ const (
 
 	// state inspection and modification methods methods
 	Method_Root          = "VmWrapperService.Root"
+	Method_SetRoot       = "VmWrapperService.SetRoot"
 	Method_StoreGet      = "VmWrapperService.StoreGet"
 	Method_StorePut      = "VmWrapperService.StorePut"
 	Method_Actor         = "VmWrapperService.Actor"
@@ -70,6 +71,19 @@ func (vs *VmWrapperService) Root() (cid.Cid, error) {
 	return out.Root, nil
 }
 
+type SetRootArgs struct {
+	Root cid.Cid
+}
+
+func (vs *VmWrapperService) SetRoot(root cid.Cid) error {
+	resp, err := vs.rpcClient.Do(Method_SetRoot, &SetRootArgs{Root: root})
+	if err != nil {
+		return err
+	}
+	log.Debugw(Method_SetRoot, "response", resp)
+	return nil
+}
+
 type StoreGetArgs struct {
 	Key cid.Cid
 }
@@ -261,6 +275,7 @@ type ApplyTipSetMessagesArgs struct {
 
 type ApplyTipSetMessagesReply struct {
 	Receipts []types.MessageReceipt
+	Rewards  []types.BlockReward
 	Root     cid.Cid
 }
 
@@ -0,0 +1,166 @@
+package drivers
+
+import (
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	market_spec "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	adt_spec "github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// checkBuiltinActorInvariants asserts the internal invariants of the state of each builtin actor. The states are
+// decoded as specs-actors v0.9 states, so the checks are skipped for other versions of the actors.
+func (td *TestDriver) checkBuiltinActorInvariants(what string) {
+	if td.Actors().Version() != actors.Version0 {
+		return
+	}
+
+	codes := td.Actors().Codes()
+	var multisigs []address.Address
+	ids := make(map[address.Address]bool)
+	err := state.ForEachActor(td.State(), func(addr address.Address, actr state.Actor) error {
+		ids[addr] = true
+		if actr.Code() == codes.Multisig {
+			multisigs = append(multisigs, addr)
+		}
+		return nil
+	})
+	require.NoError(td.T, err)
+
+	td.checkInitInvariants(what, ids)
+	td.checkPowerInvariants(what, ids)
+	td.checkMarketInvariants(what)
+	for _, addr := range multisigs {
+		td.checkMultisigInvariants(what, addr)
+	}
+}
+
+// checkInitInvariants asserts each address mapped by the init actor resolves to an actor in the state tree, with an
+// ID below the next ID to be assigned and not assigned to another address. `ids` holds the ID addresses of the
+// actors in the state tree.
+func (td *TestDriver) checkInitInvariants(what string, ids map[address.Address]bool) {
+	if !ids[builtin_spec.InitActorAddr] {
+		return
+	}
+	var st init_spec.State
+	td.GetActorState(builtin_spec.InitActorAddr, &st)
+	addrMap, err := adt_spec.AsMap(AsStore(td.State()), st.AddressMap)
+	require.NoError(td.T, err)
+
+	assigned := make(map[abi_spec.ActorID]address.Address)
+	var actorID cbg.CborInt
+	err = addrMap.ForEach(&actorID, func(k string) error {
+		addr, err := address.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		id := abi_spec.ActorID(actorID)
+		idAddr, err := address.NewIDAddress(uint64(id))
+		if err != nil {
+			return err
+		}
+		assert.True(td.T, id < st.NextID, "%s: init actor maps %s to ID %d, not below the next ID %d", what, addr, id, st.NextID)
+		assert.True(td.T, ids[idAddr], "%s: init actor maps %s to %s, which is not in the state tree", what, addr, idAddr)
+		if other, ok := assigned[id]; ok {
+			assert.Fail(td.T, "init actor invariant", "%s: init actor maps both %s and %s to ID %d", what, other, addr, id)
+		}
+		assigned[id] = addr
+		return nil
+	})
+	require.NoError(td.T, err)
+}
+
+// checkPowerInvariants asserts the power actor's committed power totals are the sums of the claims, its miner counts
+// match the claims, and each claim belongs to an actor in the state tree.
+func (td *TestDriver) checkPowerInvariants(what string, ids map[address.Address]bool) {
+	if !ids[builtin_spec.StoragePowerActorAddr] {
+		return
+	}
+	var st power_spec.State
+	td.GetActorState(builtin_spec.StoragePowerActorAddr, &st)
+	claims, err := adt_spec.AsMap(AsStore(td.State()), st.Claims)
+	require.NoError(td.T, err)
+
+	raw, qa := big_spec.Zero(), big_spec.Zero()
+	var count, aboveMin int64
+	var claim power_spec.Claim
+	err = claims.ForEach(&claim, func(k string) error {
+		miner, err := address.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		assert.True(td.T, ids[miner], "%s: power actor has a claim for %s, which is not in the state tree", what, miner)
+		raw = big_spec.Add(raw, claim.RawBytePower)
+		qa = big_spec.Add(qa, claim.QualityAdjPower)
+		count++
+		if claim.QualityAdjPower.GreaterThanEqual(power_spec.ConsensusMinerMinPower) {
+			aboveMin++
+		}
+		return nil
+	})
+	require.NoError(td.T, err)
+
+	assert.Equal(td.T, count, st.MinerCount, "%s: power actor MinerCount %d, but %d claims", what, st.MinerCount, count)
+	assert.Equal(td.T, aboveMin, st.MinerAboveMinPowerCount, "%s: power actor MinerAboveMinPowerCount %d, but %d claims above the minimum", what, st.MinerAboveMinPowerCount, aboveMin)
+	assert.True(td.T, raw.Equals(st.TotalBytesCommitted), "%s: power actor TotalBytesCommitted %s, but claims sum to %s", what, st.TotalBytesCommitted, raw)
+	assert.True(td.T, qa.Equals(st.TotalQABytesCommitted), "%s: power actor TotalQABytesCommitted %s, but claims sum to %s", what, st.TotalQABytesCommitted, qa)
+}
+
+// checkMarketInvariants asserts the market actor's escrow covers the funds it holds locked for each address.
+func (td *TestDriver) checkMarketInvariants(what string) {
+	if _, err := td.State().Actor(builtin_spec.StorageMarketActorAddr); err != nil {
+		return
+	}
+	var st market_spec.State
+	td.GetActorState(builtin_spec.StorageMarketActorAddr, &st)
+	escrow, err := adt_spec.AsMap(AsStore(td.State()), st.EscrowTable)
+	require.NoError(td.T, err)
+	locked, err := adt_spec.AsMap(AsStore(td.State()), st.LockedTable)
+	require.NoError(td.T, err)
+
+	var lockedAmt abi_spec.TokenAmount
+	err = locked.ForEach(&lockedAmt, func(k string) error {
+		addr, err := address.NewFromBytes([]byte(k))
+		if err != nil {
+			return err
+		}
+		escrowAmt := big_spec.Zero()
+		if _, err := escrow.Get(adt_spec.AddrKey(addr), &escrowAmt); err != nil {
+			return err
+		}
+		assert.True(td.T, lockedAmt.LessThanEqual(escrowAmt), "%s: market actor locks %s for %s, more than its escrow %s", what, lockedAmt, addr, escrowAmt)
+		return nil
+	})
+	require.NoError(td.T, err)
+}
+
+// checkMultisigInvariants asserts the IDs of the pending transactions of the multisig at `addr` are below its next
+// transaction ID, and that its signers can meet its threshold.
+func (td *TestDriver) checkMultisigInvariants(what string, addr address.Address) {
+	var st multisig_spec.State
+	td.GetActorState(addr, &st)
+	assert.True(td.T, st.NumApprovalsThreshold <= uint64(len(st.Signers)), "%s: multisig %s has threshold %d but %d signers", what, addr, st.NumApprovalsThreshold, len(st.Signers))
+
+	txns, err := adt_spec.AsMap(AsStore(td.State()), st.PendingTxns)
+	require.NoError(td.T, err)
+	var txn multisig_spec.Transaction
+	err = txns.ForEach(&txn, func(k string) error {
+		id, err := adt_spec.ParseIntKey(k)
+		if err != nil {
+			return err
+		}
+		assert.True(td.T, id < int64(st.NextTxnID), "%s: multisig %s has pending transaction %d, not below NextTxnID %d", what, addr, id, st.NextTxnID)
+		return nil
+	})
+	require.NoError(td.T, err)
+}
@@ -1,15 +1,10 @@
 package drivers
 
 import (
-	big_spec "github.com/filecoin-project/go-state-types/big"
-)
-
-const (
-	totalFilecoin     = 2_000_000_000
-	filecoinPrecision = 1_000_000_000_000_000_000
+	"github.com/filecoin-project/chain-validation/chain/actors"
 )
 
 var (
-	TotalNetworkBalance = big_spec.Mul(big_spec.NewInt(totalFilecoin), big_spec.NewInt(filecoinPrecision))
+	TotalNetworkBalance = actors.TotalNetworkBalance
 	EmptyReturnValue    = []byte{}
 )
@@ -0,0 +1,229 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	acrypto "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// DifferentialDriver applies every message and tipset to two implementations in lockstep, comparing the receipts,
+// penalties, rewards and state roots they produce instead of validating them against recorded expectations. When the
+// state roots differ the actors whose state differs are reported.
+type DifferentialDriver struct {
+	T testing.TB
+	// A and B drive the two implementations. They share keys, so accounts created through the DifferentialDriver
+	// have the same addresses in both.
+	A *TestDriver
+	B *TestDriver
+
+	MessageProducer *chain.MessageProducer
+
+	step int
+}
+
+// NewDifferentialDriver builds a driver for each factory, calling each `configure` function on both builders first,
+// and checks that the two start from the same state.
+func NewDifferentialDriver(t testing.TB, factoryA, factoryB state.Factories, configure ...func(*TestDriverBuilder)) *DifferentialDriver {
+	keys := &sharedKeyManager{src: factoryA.NewKeyManager()}
+	build := func(factory state.Factories) *TestDriver {
+		b := NewBuilder(context.Background(), factory).WithKeyManager(keys.view())
+		for _, c := range configure {
+			c(b)
+		}
+		return b.Build(t)
+	}
+
+	dd := &DifferentialDriver{T: t, A: build(factoryA), B: build(factoryB)}
+	dd.MessageProducer = dd.A.MessageProducer
+	dd.compareRoots("genesis")
+	return dd
+}
+
+// SetEpoch sets the epoch subsequent messages and tipsets are applied at in both implementations.
+func (dd *DifferentialDriver) SetEpoch(epoch abi_spec.ChainEpoch) {
+	dd.A.ExeCtx.Epoch = epoch
+	dd.B.ExeCtx.Epoch = epoch
+}
+
+// NewAccountActor installs a new account actor in both implementations, returning its addresses.
+func (dd *DifferentialDriver) NewAccountActor(addrType address.Protocol, balance abi_spec.TokenAmount) (pubkey address.Address, id address.Address) {
+	pubkey, id = dd.A.NewAccountActor(addrType, balance)
+	pubkeyB, idB := dd.B.NewAccountActor(addrType, balance)
+	require.Equal(dd.T, pubkey, pubkeyB, "implementations created accounts with different keys")
+	require.Equal(dd.T, id, idB, "implementations assigned different IDs to account %s", pubkey)
+	return pubkey, id
+}
+
+// ApplyMessage applies an unsigned message to both implementations, returning their results.
+func (dd *DifferentialDriver) ApplyMessage(msg *types.Message) (a, b types.ApplyMessageResult) {
+	desc := dd.nextStep("message")
+	a, b = dd.A.applyMessage(msg), dd.B.applyMessage(msg)
+	dd.compareMessageResults(desc, a, b)
+	return a, b
+}
+
+// ApplySigned applies a message signed by its sender to both implementations, returning their results.
+func (dd *DifferentialDriver) ApplySigned(msg *types.Message) (a, b types.ApplyMessageResult) {
+	desc := dd.nextStep("signed message")
+	a, b = dd.A.applyMessageSigned(msg), dd.B.applyMessageSigned(msg)
+	dd.compareMessageResults(desc, a, b)
+	return a, b
+}
+
+// NewBlockBuilder returns a builder for a block mined by `miner` for ApplyTipSet. Results expected by the builder are
+// ignored.
+func (dd *DifferentialDriver) NewBlockBuilder(miner address.Address) *BlockBuilder {
+	return NewBlockBuilder(dd.A, miner)
+}
+
+// ApplyTipSet applies a tipset holding the blocks of `bbs` to both implementations, returning their results.
+func (dd *DifferentialDriver) ApplyTipSet(bbs ...*BlockBuilder) (a, b types.ApplyTipSetResult) {
+	desc := dd.nextStep(fmt.Sprintf("tipset at epoch %d", dd.A.ExeCtx.Epoch))
+	var blks []types.BlockMessagesInfo
+	for _, bb := range bbs {
+		blks = append(blks, bb.build())
+	}
+	a, b = dd.A.applyTipSet(blks), dd.B.applyTipSet(blks)
+	if dd.panicked(desc) {
+		return a, b
+	}
+
+	if assert.Equal(dd.T, len(a.Receipts), len(b.Receipts), "%s: A returned %d receipts, B returned %d", desc, len(a.Receipts), len(b.Receipts)) {
+		for i := range a.Receipts {
+			dd.compareReceipts(fmt.Sprintf("%s message %d", desc, i), a.Receipts[i], b.Receipts[i])
+		}
+	}
+	if assert.Equal(dd.T, len(a.Rewards), len(b.Rewards), "%s: A returned rewards for %d blocks, B for %d", desc, len(a.Rewards), len(b.Rewards)) {
+		for i := range a.Rewards {
+			ra, rb := a.Rewards[i], b.Rewards[i]
+			assert.Equal(dd.T, ra.Miner, rb.Miner, "%s block %d: A Miner: %s B Miner: %s", desc, i, ra.Miner, rb.Miner)
+			dd.compareTokenAmounts(fmt.Sprintf("%s block %d Penalty", desc, i), ra.Penalty, rb.Penalty)
+			dd.compareTokenAmounts(fmt.Sprintf("%s block %d Reward", desc, i), ra.Reward, rb.Reward)
+		}
+	}
+	dd.compareRoots(desc)
+	return a, b
+}
+
+func (dd *DifferentialDriver) nextStep(what string) string {
+	dd.step++
+	return fmt.Sprintf("step %d (%s)", dd.step, what)
+}
+
+// panicked returns true if either implementation panicked applying the last message or tipset. The panics are
+// reported by the drivers, so the results are not compared.
+func (dd *DifferentialDriver) panicked(desc string) bool {
+	if dd.A.lastPanic == nil && dd.B.lastPanic == nil {
+		return false
+	}
+	dd.T.Logf("%s: not comparing results, A panicked: %t B panicked: %t", desc, dd.A.lastPanic != nil, dd.B.lastPanic != nil)
+	return true
+}
+
+func (dd *DifferentialDriver) compareMessageResults(desc string, a, b types.ApplyMessageResult) {
+	if dd.panicked(desc) {
+		return
+	}
+	dd.compareReceipts(desc, a.Receipt, b.Receipt)
+	dd.compareTokenAmounts(desc+" Penalty", a.Penalty, b.Penalty)
+	dd.compareTokenAmounts(desc+" Reward", a.Reward, b.Reward)
+	dd.compareRoots(desc)
+}
+
+func (dd *DifferentialDriver) compareReceipts(desc string, a, b types.MessageReceipt) {
+	assert.Equal(dd.T, a.ExitCode, b.ExitCode, "%s: A ExitCode: %s B ExitCode: %s", desc, a.ExitCode.Error(), b.ExitCode.Error())
+	assert.True(dd.T, bytes.Equal(a.ReturnValue, b.ReturnValue), "%s: A ReturnValue: %x B ReturnValue: %x", desc, a.ReturnValue, b.ReturnValue)
+	assert.Equal(dd.T, a.GasUsed, b.GasUsed, "%s: A GasUsed: %d B GasUsed: %d", desc, a.GasUsed, b.GasUsed)
+}
+
+func (dd *DifferentialDriver) compareTokenAmounts(desc string, a, b abi_spec.TokenAmount) {
+	if a.Nil() || b.Nil() {
+		assert.Equal(dd.T, a.Nil(), b.Nil(), "%s: A: %v B: %v", desc, a, b)
+		return
+	}
+	assert.True(dd.T, a.Equals(b), "%s: A: %s B: %s", desc, a, b)
+}
+
+// compareRoots compares the state roots of the implementations, reporting the actors that differ if they do.
+func (dd *DifferentialDriver) compareRoots(desc string) {
+	rootA, rootB := dd.A.State().Root(), dd.B.State().Root()
+	if rootA.Equals(rootB) {
+		return
+	}
+	assert.Fail(dd.T, "state roots differ", "%s: A StateRoot: %s B StateRoot: %s", desc, rootA, rootB)
+
+	actorsA, actorsB := dd.A.SnapshotAllActors(), dd.B.SnapshotAllActors()
+	var addrs []address.Address
+	for addr := range actorsA {
+		addrs = append(addrs, addr)
+	}
+	for addr := range actorsB {
+		if _, ok := actorsA[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].String() < addrs[j].String() })
+
+	for _, addr := range addrs {
+		a, inA := actorsA[addr]
+		b, inB := actorsB[addr]
+		switch {
+		case !inA:
+			dd.T.Errorf("%s: actor %s only exists in B: %+v", desc, addr, b)
+		case !inB:
+			dd.T.Errorf("%s: actor %s only exists in A: %+v", desc, addr, a)
+		case a.Code != b.Code || a.Head != b.Head || a.CallSeqNum != b.CallSeqNum || !a.Balance.Equals(b.Balance):
+			dd.T.Errorf("%s: actor %s differs\n\tA: Code: %s Head: %s CallSeqNum: %d Balance: %s\n\tB: Code: %s Head: %s CallSeqNum: %d Balance: %s",
+				desc, addr, a.Code, a.Head, a.CallSeqNum, a.Balance, b.Code, b.Head, b.CallSeqNum, b.Balance)
+		}
+	}
+}
+
+// sharedKeyManager hands the same sequence of keys to each of its views, so drivers built with one view each create
+// the same accounts.
+type sharedKeyManager struct {
+	src  state.KeyManager
+	secp []address.Address
+	bls  []address.Address
+}
+
+func (s *sharedKeyManager) view() state.KeyManager {
+	return &sharedKeyView{shared: s}
+}
+
+type sharedKeyView struct {
+	shared    *sharedKeyManager
+	secp, bls int
+}
+
+func (v *sharedKeyView) NewSECP256k1AccountAddress() address.Address {
+	if v.secp == len(v.shared.secp) {
+		v.shared.secp = append(v.shared.secp, v.shared.src.NewSECP256k1AccountAddress())
+	}
+	v.secp++
+	return v.shared.secp[v.secp-1]
+}
+
+func (v *sharedKeyView) NewBLSAccountAddress() address.Address {
+	if v.bls == len(v.shared.bls) {
+		v.shared.bls = append(v.shared.bls, v.shared.src.NewBLSAccountAddress())
+	}
+	v.bls++
+	return v.shared.bls[v.bls-1]
+}
+
+func (v *sharedKeyView) Sign(addr address.Address, data []byte) (acrypto.Signature, error) {
+	return v.shared.src.Sign(addr, data)
+}
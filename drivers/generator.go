@@ -0,0 +1,521 @@
+package drivers
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	crypto_spec "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	paych_spec "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	puppet_spec "github.com/filecoin-project/specs-actors/actors/puppet"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// MessageKind is the kind of a generated message.
+type MessageKind int
+
+const (
+	KindTransfer MessageKind = iota
+	KindCreateMultisig
+	KindMultisigPropose
+	KindMultisigApprove
+	KindCreatePaych
+	KindPaychUpdate
+	KindPaychSettle
+	KindPuppetSend
+)
+
+func (k MessageKind) String() string {
+	switch k {
+	case KindTransfer:
+		return "Transfer"
+	case KindCreateMultisig:
+		return "CreateMultisig"
+	case KindMultisigPropose:
+		return "MultisigPropose"
+	case KindMultisigApprove:
+		return "MultisigApprove"
+	case KindCreatePaych:
+		return "CreatePaych"
+	case KindPaychUpdate:
+		return "PaychUpdate"
+	case KindPaychSettle:
+		return "PaychSettle"
+	case KindPuppetSend:
+		return "PuppetSend"
+	default:
+		return fmt.Sprintf("MessageKind(%d)", int(k))
+	}
+}
+
+// GeneratorConfig tunes the messages produced by a MessageGenerator.
+type GeneratorConfig struct {
+	// InvalidNonceRate, InsufficientBalanceRate and InsufficientGasRate are the probabilities of a message having a
+	// wrong call sequence number, a value exceeding the balance of its sender, and a gas limit too low to execute it.
+	InvalidNonceRate        float64
+	InsufficientBalanceRate float64
+	InsufficientGasRate     float64
+	// SignedRate is the probability of a message from a SECP account being applied as a signed message.
+	SignedRate float64
+	// NextEpochRate is the probability of the epoch advancing before a message.
+	NextEpochRate float64
+	// Puppet is the address of a puppet actor to send nested messages through, none when undefined.
+	Puppet address.Address
+}
+
+// DefaultGeneratorConfig produces mostly valid messages, a few percent of them invalid in each way.
+var DefaultGeneratorConfig = GeneratorConfig{
+	InvalidNonceRate:        0.05,
+	InsufficientBalanceRate: 0.05,
+	InsufficientGasRate:     0.05,
+	SignedRate:              0.5,
+	NextEpochRate:           0.1,
+}
+
+// GeneratorAccount is an account messages are generated from and to.
+type GeneratorAccount struct {
+	Pubkey address.Address
+	ID     address.Address
+}
+
+// GeneratedMessage is a message produced by a MessageGenerator, to be applied at Epoch.
+type GeneratedMessage struct {
+	Kind    MessageKind
+	Message *types.Message
+	// Signed is true if the message is to be applied as a signed message.
+	Signed bool
+	Epoch  abi_spec.ChainEpoch
+}
+
+func (gm *GeneratedMessage) String() string {
+	return fmt.Sprintf("%s at epoch %d from %s to %s (nonce %d, value %s, gas limit %d)",
+		gm.Kind, gm.Epoch, gm.Message.From, gm.Message.To, gm.Message.CallSeqNum, gm.Message.Value, gm.Message.GasLimit)
+}
+
+type genMultisig struct {
+	addr      address.Address
+	signers   []address.Address
+	threshold int
+	nextTxnID int64
+	// approvers of each pending transaction, by transaction ID
+	pending map[int64][]address.Address
+}
+
+type genLane struct {
+	nonce  uint64
+	amount abi_spec.TokenAmount
+}
+
+type genPaych struct {
+	addr     address.Address
+	from, to address.Address
+	lanes    map[uint64]*genLane
+}
+
+// MessageGenerator produces a random but reproducible sequence of plausibly valid messages between a set of accounts:
+// transfers, multisig and payment channel creation through the init actor, multisig proposals and approvals, payment
+// channel updates and nested sends through a puppet actor. It tracks the actors created by the messages it observed
+// succeeding, so later messages use them.
+type MessageGenerator struct {
+	cfg      GeneratorConfig
+	rnd      *rand.Rand
+	mp       *chain.MessageProducer
+	accounts []GeneratorAccount
+	epoch    abi_spec.ChainEpoch
+
+	// addresses that received funds without being generator accounts
+	recipients []address.Address
+	multisigs  []*genMultisig
+	paychs     []*genPaych
+
+	// records the actors created by the last message generated, if it succeeds
+	observed func(rct types.MessageReceipt)
+}
+
+// NewMessageGenerator returns a generator seeded with `seed`, producing messages with `mp` between `accounts` from
+// epoch `epoch` on.
+func NewMessageGenerator(seed int64, cfg GeneratorConfig, mp *chain.MessageProducer, accounts []GeneratorAccount, epoch abi_spec.ChainEpoch) *MessageGenerator {
+	return &MessageGenerator{
+		cfg:      cfg,
+		rnd:      rand.New(rand.NewSource(seed)),
+		mp:       mp,
+		accounts: accounts,
+		epoch:    epoch,
+	}
+}
+
+// Next returns the next message, reading the call sequence numbers and balances of its sender from `st`. The result
+// of applying it must be passed to Observe before the next call.
+func (g *MessageGenerator) Next(st state.VMWrapper) (*GeneratedMessage, error) {
+	if g.rnd.Float64() < g.cfg.NextEpochRate {
+		g.epoch++
+	}
+
+	kinds := []MessageKind{KindTransfer, KindTransfer, KindCreateMultisig, KindCreatePaych}
+	if len(g.multisigs) > 0 {
+		kinds = append(kinds, KindMultisigPropose, KindMultisigPropose)
+		for _, ms := range g.multisigs {
+			if len(ms.pending) > 0 {
+				kinds = append(kinds, KindMultisigApprove, KindMultisigApprove)
+				break
+			}
+		}
+	}
+	if len(g.paychs) > 0 {
+		kinds = append(kinds, KindPaychUpdate, KindPaychUpdate, KindPaychSettle)
+	}
+	if g.cfg.Puppet != address.Undef {
+		kinds = append(kinds, KindPuppetSend)
+	}
+	kind := kinds[g.rnd.Intn(len(kinds))]
+
+	var msg *types.Message
+	var err error
+	g.observed = nil
+	switch kind {
+	case KindTransfer:
+		msg, err = g.transfer(st)
+	case KindCreateMultisig:
+		msg, err = g.createMultisig(st)
+	case KindMultisigPropose:
+		msg, err = g.multisigPropose(st)
+	case KindMultisigApprove:
+		msg, err = g.multisigApprove(st)
+	case KindCreatePaych:
+		msg, err = g.createPaych(st)
+	case KindPaychUpdate:
+		msg, err = g.paychUpdate(st)
+	case KindPaychSettle:
+		msg, err = g.paychSettle(st)
+	case KindPuppetSend:
+		msg, err = g.puppetSend(st)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// signed messages must be from the public key address of their sender.
+	signed := g.pubkey(msg.From).Protocol() == address.SECP256K1 && g.rnd.Float64() < g.cfg.SignedRate
+	if signed {
+		msg.From = g.pubkey(msg.From)
+	}
+	return &GeneratedMessage{
+		Kind:    kind,
+		Message: msg,
+		Signed:  signed,
+		Epoch:   g.epoch,
+	}, nil
+}
+
+// Observe records the receipt of the last message generated, tracking the actors it created.
+func (g *MessageGenerator) Observe(rct types.MessageReceipt) {
+	if g.observed != nil && rct.ExitCode == exitcode.Ok {
+		g.observed(rct)
+	}
+	g.observed = nil
+}
+
+func (g *MessageGenerator) account() GeneratorAccount {
+	return g.accounts[g.rnd.Intn(len(g.accounts))]
+}
+
+func (g *MessageGenerator) pubkey(id address.Address) address.Address {
+	for _, acct := range g.accounts {
+		if acct.ID == id {
+			return acct.Pubkey
+		}
+	}
+	return id
+}
+
+// recipient returns an account, by either of its addresses, or occasionally an address receiving funds for the first
+// time.
+func (g *MessageGenerator) recipient() address.Address {
+	switch n := g.rnd.Intn(10); {
+	case n == 0:
+		var key [32]byte
+		g.rnd.Read(key[:]) // nolint: errcheck
+		addr, err := address.NewSecp256k1Address(key[:])
+		if err != nil {
+			panic(err)
+		}
+		g.recipients = append(g.recipients, addr)
+		return addr
+	case n == 1 && len(g.recipients) > 0:
+		return g.recipients[g.rnd.Intn(len(g.recipients))]
+	case n < 5:
+		return g.account().Pubkey
+	default:
+		return g.account().ID
+	}
+}
+
+// opts returns the call sequence number, value and gas options of a message from `from` transferring up to `maxValue`,
+// invalid at the configured rates.
+func (g *MessageGenerator) opts(st state.VMWrapper, from address.Address, maxValue int64) ([]chain.MsgOpt, error) {
+	actr, err := st.Actor(from)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := actr.CallSeqNum()
+	if g.rnd.Float64() < g.cfg.InvalidNonceRate {
+		if nonce > 0 && g.rnd.Intn(2) == 0 {
+			nonce -= uint64(1 + g.rnd.Intn(int(nonce)))
+		} else {
+			nonce += uint64(1 + g.rnd.Intn(3))
+		}
+	}
+
+	value := big_spec.Zero()
+	if maxValue > 0 {
+		value = big_spec.NewInt(g.rnd.Int63n(maxValue + 1))
+	}
+	if g.rnd.Float64() < g.cfg.InsufficientBalanceRate {
+		value = big_spec.Add(actr.Balance(), big_spec.NewInt(1+g.rnd.Int63n(1000)))
+	}
+
+	opts := []chain.MsgOpt{chain.Nonce(nonce), chain.Value(value)}
+	if g.rnd.Float64() < g.cfg.InsufficientGasRate {
+		opts = append(opts, chain.GasLimit(1+g.rnd.Int63n(10_000)))
+	}
+	return opts, nil
+}
+
+func (g *MessageGenerator) transfer(st state.VMWrapper) (*types.Message, error) {
+	from := g.account().ID
+	opts, err := g.opts(st, from, 1_000_000)
+	if err != nil {
+		return nil, err
+	}
+	return g.mp.Transfer(from, g.recipient(), opts...), nil
+}
+
+func (g *MessageGenerator) createMultisig(st state.VMWrapper) (*types.Message, error) {
+	from := g.account().ID
+	seen := make(map[address.Address]bool)
+	var signers []address.Address
+	for i := 0; i < 1+g.rnd.Intn(3); i++ {
+		signer := g.account().ID
+		if !seen[signer] {
+			seen[signer] = true
+			signers = append(signers, signer)
+		}
+	}
+	threshold := 1 + g.rnd.Intn(len(signers))
+
+	opts, err := g.opts(st, from, 10_000_000)
+	if err != nil {
+		return nil, err
+	}
+	g.observed = func(rct types.MessageReceipt) {
+		var ret init_spec.ExecReturn
+		if err := ret.UnmarshalCBOR(bytes.NewReader(rct.ReturnValue)); err != nil {
+			return
+		}
+		g.multisigs = append(g.multisigs, &genMultisig{
+			addr:      ret.IDAddress,
+			signers:   signers,
+			threshold: threshold,
+			pending:   make(map[int64][]address.Address),
+		})
+	}
+	return g.mp.CreateMultisigActor(from, signers, 0, uint64(threshold), opts...), nil
+}
+
+func (g *MessageGenerator) multisigPropose(st state.VMWrapper) (*types.Message, error) {
+	ms := g.multisigs[g.rnd.Intn(len(g.multisigs))]
+	from := ms.signers[g.rnd.Intn(len(ms.signers))]
+	if g.rnd.Intn(10) == 0 {
+		// not necessarily a signer
+		from = g.account().ID
+	}
+	opts, err := g.opts(st, from, 0)
+	if err != nil {
+		return nil, err
+	}
+	g.observed = func(types.MessageReceipt) {
+		id := ms.nextTxnID
+		ms.nextTxnID++
+		if ms.threshold > 1 {
+			ms.pending[id] = []address.Address{from}
+		}
+	}
+	return g.mp.MultisigPropose(from, ms.addr, &multisig_spec.ProposeParams{
+		To:     g.recipient(),
+		Value:  big_spec.NewInt(g.rnd.Int63n(1_000_000)),
+		Method: builtin_spec.MethodSend,
+		Params: nil,
+	}, opts...), nil
+}
+
+func (g *MessageGenerator) multisigApprove(st state.VMWrapper) (*types.Message, error) {
+	var candidates []*genMultisig
+	for _, ms := range g.multisigs {
+		if len(ms.pending) > 0 {
+			candidates = append(candidates, ms)
+		}
+	}
+	ms := candidates[g.rnd.Intn(len(candidates))]
+	// map iteration order is random, pick the lowest pending ID to stay reproducible.
+	id := ms.nextTxnID
+	for pending := range ms.pending {
+		if pending < id {
+			id = pending
+		}
+	}
+	from := ms.signers[g.rnd.Intn(len(ms.signers))]
+	opts, err := g.opts(st, from, 0)
+	if err != nil {
+		return nil, err
+	}
+	g.observed = func(types.MessageReceipt) {
+		ms.pending[id] = append(ms.pending[id], from)
+		if len(ms.pending[id]) >= ms.threshold {
+			delete(ms.pending, id)
+		}
+	}
+	return g.mp.MultisigApprove(from, ms.addr, &multisig_spec.TxnIDParams{ID: multisig_spec.TxnID(id)}, opts...), nil
+}
+
+func (g *MessageGenerator) createPaych(st state.VMWrapper) (*types.Message, error) {
+	from, to := g.account(), g.account()
+	opts, err := g.opts(st, from.ID, 10_000_000)
+	if err != nil {
+		return nil, err
+	}
+	g.observed = func(rct types.MessageReceipt) {
+		var ret init_spec.ExecReturn
+		if err := ret.UnmarshalCBOR(bytes.NewReader(rct.ReturnValue)); err != nil {
+			return
+		}
+		g.paychs = append(g.paychs, &genPaych{
+			addr:  ret.IDAddress,
+			from:  from.ID,
+			to:    to.ID,
+			lanes: make(map[uint64]*genLane),
+		})
+	}
+	return g.mp.CreatePaymentChannelActor(from.Pubkey, to.Pubkey, opts...), nil
+}
+
+func (g *MessageGenerator) paychUpdate(st state.VMWrapper) (*types.Message, error) {
+	pc := g.paychs[g.rnd.Intn(len(g.paychs))]
+	laneID := uint64(g.rnd.Intn(3))
+	lane, ok := pc.lanes[laneID]
+	if !ok {
+		lane = &genLane{amount: big_spec.Zero()}
+	}
+	nonce := lane.nonce + 1
+	amount := big_spec.Add(lane.amount, big_spec.NewInt(g.rnd.Int63n(100_000)))
+
+	opts, err := g.opts(st, pc.to, 0)
+	if err != nil {
+		return nil, err
+	}
+	g.observed = func(types.MessageReceipt) {
+		pc.lanes[laneID] = &genLane{nonce: nonce, amount: amount}
+	}
+	// the syscalls of the drivers accept any signature.
+	return g.mp.PaychUpdateChannelState(pc.to, pc.addr, &paych_spec.UpdateChannelStateParams{
+		Sv: paych_spec.SignedVoucher{
+			ChannelAddr: pc.addr,
+			Lane:        laneID,
+			Nonce:       nonce,
+			Amount:      amount,
+			Signature: &crypto_spec.Signature{
+				Type: crypto_spec.SigTypeSecp256k1,
+				Data: []byte("generated voucher"),
+			},
+		},
+	}, opts...), nil
+}
+
+func (g *MessageGenerator) paychSettle(st state.VMWrapper) (*types.Message, error) {
+	pc := g.paychs[g.rnd.Intn(len(g.paychs))]
+	from := pc.from
+	if g.rnd.Intn(2) == 0 {
+		from = pc.to
+	}
+	opts, err := g.opts(st, from, 0)
+	if err != nil {
+		return nil, err
+	}
+	return g.mp.PaychSettle(from, pc.addr, nil, opts...), nil
+}
+
+func (g *MessageGenerator) puppetSend(st state.VMWrapper) (*types.Message, error) {
+	from := g.account().ID
+	opts, err := g.opts(st, from, 0)
+	if err != nil {
+		return nil, err
+	}
+	return g.mp.PuppetSend(from, g.cfg.Puppet, &puppet_spec.SendParams{
+		To:     g.recipient(),
+		Value:  big_spec.Zero(),
+		Method: builtin_spec.MethodSend,
+		Params: nil,
+	}, opts...), nil
+}
+
+// NewGeneratorAccounts installs `n` accounts with `balance`, alternating SECP and BLS keys, to generate messages
+// between.
+func (d *StateDriver) NewGeneratorAccounts(n int, balance abi_spec.TokenAmount) []GeneratorAccount {
+	accounts := make([]GeneratorAccount, n)
+	for i := range accounts {
+		protocol := SECP
+		if i%2 == 1 {
+			protocol = BLS
+		}
+		accounts[i].Pubkey, accounts[i].ID = d.NewAccountActor(protocol, balance)
+	}
+	return accounts
+}
+
+// ApplyGenerated applies `n` messages generated by `g`, without validating them against recorded expectations.
+// Invariants are checked when enabled by the validation config. The message applied at each step is logged.
+func (td *TestDriver) ApplyGenerated(g *MessageGenerator, n int) {
+	for i := 0; i < n; i++ {
+		gm, err := g.Next(td.State())
+		require.NoError(td.T, err)
+		td.T.Logf("generated message %d: %s", i, gm)
+
+		td.ExeCtx.Epoch = gm.Epoch
+		var result types.ApplyMessageResult
+		if gm.Signed {
+			result = td.applyMessageSigned(gm.Message)
+		} else {
+			result = td.applyMessage(gm.Message)
+		}
+		g.Observe(result.Receipt)
+	}
+}
+
+// ApplyGenerated applies `n` messages generated by `g` to both implementations, comparing their results.
+func (dd *DifferentialDriver) ApplyGenerated(g *MessageGenerator, n int) {
+	for i := 0; i < n; i++ {
+		gm, err := g.Next(dd.A.State())
+		require.NoError(dd.T, err)
+		dd.T.Logf("generated message %d: %s", i, gm)
+
+		dd.SetEpoch(gm.Epoch)
+		var result types.ApplyMessageResult
+		if gm.Signed {
+			result, _ = dd.ApplySigned(gm.Message)
+		} else {
+			result, _ = dd.ApplyMessage(gm.Message)
+		}
+		g.Observe(result.Receipt)
+	}
+}
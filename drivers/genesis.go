@@ -0,0 +1,243 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	miner_spec "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// DefaultGenesisDealDuration is the duration of the deals in pre-sealed sectors unless a miner sets another.
+const DefaultGenesisDealDuration = 180 * builtin_spec.EpochsInDay
+
+// GenesisProvingPeriodStart is the epoch the proving period of genesis miners starts, the latest the miner constructor
+// would pick at genesis. Miners with pre-sealed sectors owe Window PoSts for them from there.
+var GenesisProvingPeriodStart = miner_spec.WPoStProvingPeriod
+
+// GenesisSpec declares the actors a test starts from in addition to the builtin actors. The driver installs them in
+// the order they are declared, accounts first, then multisigs, then miners, so the genesis state root is the same on
+// every run. Actors refer to each other by name, names being unique across the spec.
+type GenesisSpec struct {
+	Accounts  []GenesisAccount
+	Multisigs []GenesisMultisig
+	Miners    []GenesisMiner
+
+	// ThisEpochReward overrides the reward paid for the first epoch when set. The network power is the sum of the
+	// power of the miners' pre-sealed sectors.
+	ThisEpochReward abi_spec.TokenAmount
+}
+
+// GenesisAccount is an account actor installed at genesis.
+type GenesisAccount struct {
+	Name    string
+	Type    address.Protocol
+	Balance abi_spec.TokenAmount
+}
+
+// GenesisMultisig is a multisig actor installed at genesis.
+type GenesisMultisig struct {
+	Name string
+	// Names of the accounts signing for the multisig.
+	Signers   []string
+	Threshold uint64
+	Balance   abi_spec.TokenAmount
+	// UnlockDuration vests the balance linearly from genesis when non-zero.
+	UnlockDuration abi_spec.ChainEpoch
+}
+
+// GenesisMiner is a miner actor installed at genesis, with power for its pre-sealed sectors.
+type GenesisMiner struct {
+	Name string
+	// Names of the owner and worker accounts.
+	Owner  string
+	Worker string
+	// SealProofType of the miner, the zero value being that of TestSealProofType.
+	SealProofType abi_spec.RegisteredSealProof
+
+	// PreSeals is the number of sectors committed at genesis, each holding an active deal with DealClient.
+	PreSeals int
+	// Name of the account the deals are made with, the owner when empty.
+	DealClient string
+	// DealDuration of the deals, DefaultGenesisDealDuration when zero. Sectors expire with their deal.
+	DealDuration abi_spec.ChainEpoch
+}
+
+// GenesisActor holds the addresses of an actor installed from a GenesisSpec.
+type GenesisActor struct {
+	// Robust is the public key address of accounts, and the actor address of other actors.
+	Robust address.Address
+	ID     address.Address
+}
+
+// Genesis holds the actors installed from a GenesisSpec.
+type Genesis struct {
+	d      *StateDriver
+	actors map[string]GenesisActor
+
+	// Sectors holds the pre-sealed sectors of the miners, keyed by miner ID address.
+	Sectors *MockSectorBuilder
+}
+
+// Actor returns the addresses of the actor named `name` in the spec, failing the test if there is none.
+func (g *Genesis) Actor(name string) GenesisActor {
+	act, ok := g.actors[name]
+	if !ok {
+		require.FailNowf(g.d.tb, "unknown genesis actor", "no actor named %q in the genesis spec", name)
+	}
+	return act
+}
+
+// ID returns the ID address of the actor named `name` in the spec.
+func (g *Genesis) ID(name string) address.Address {
+	return g.Actor(name).ID
+}
+
+// Robust returns the robust address of the actor named `name` in the spec.
+func (g *Genesis) Robust(name string) address.Address {
+	return g.Actor(name).Robust
+}
+
+func (g *Genesis) add(name string, act GenesisActor) {
+	if name == "" {
+		require.FailNow(g.d.tb, "genesis actors must be named")
+	}
+	if _, ok := g.actors[name]; ok {
+		require.FailNowf(g.d.tb, "duplicate genesis actor", "more than one actor named %q in the genesis spec", name)
+	}
+	g.actors[name] = act
+}
+
+// genesisActorAddress returns the robust address of the non-account actor named `name`.
+func genesisActorAddress(tb testing.TB, name string) address.Address {
+	addr, err := address.NewActorAddress([]byte("chain-validation/genesis/" + name))
+	require.NoError(tb, err)
+	return addr
+}
+
+// installGenesis installs the actors of `spec` in the state, which must already hold the builtin actors.
+func (d *StateDriver) installGenesis(spec GenesisSpec) *Genesis {
+	g := &Genesis{
+		d:       d,
+		actors:  make(map[string]GenesisActor),
+		Sectors: NewMockSectorBuilder(d.tb),
+	}
+	store := AsStore(d.st)
+
+	if !spec.ThisEpochReward.Nil() {
+		reward, err := d.st.Actor(builtin_spec.RewardActorAddr)
+		require.NoError(d.tb, err)
+		_, err = d.st.SetActorState(builtin_spec.RewardActorAddr, reward.Balance(), d.av.NewRewardState(spec.ThisEpochReward))
+		require.NoError(d.tb, err)
+	}
+
+	for _, acct := range spec.Accounts {
+		balance := acct.Balance
+		if balance.Nil() {
+			balance = big_spec.Zero()
+		}
+		pubkey, id := d.NewAccountActor(acct.Type, balance)
+		g.add(acct.Name, GenesisActor{Robust: pubkey, ID: id})
+	}
+
+	for _, ms := range spec.Multisigs {
+		balance := ms.Balance
+		if balance.Nil() {
+			balance = big_spec.Zero()
+		}
+		signers := make([]address.Address, len(ms.Signers))
+		for i, name := range ms.Signers {
+			signers[i] = g.ID(name)
+		}
+		msState, err := d.av.NewMultisigState(store, actors.MultisigParams{
+			Signers:               signers,
+			NumApprovalsThreshold: ms.Threshold,
+			InitialBalance:        balance,
+			StartEpoch:            0,
+			UnlockDuration:        ms.UnlockDuration,
+		})
+		require.NoError(d.tb, err)
+
+		robust := genesisActorAddress(d.tb, ms.Name)
+		_, id, err := d.st.CreateActor(d.av.Codes().Multisig, robust, balance, msState)
+		require.NoError(d.tb, err)
+		g.add(ms.Name, GenesisActor{Robust: robust, ID: id})
+	}
+
+	for _, m := range spec.Miners {
+		d.installGenesisMiner(g, m)
+	}
+	return g
+}
+
+func (d *StateDriver) installGenesisMiner(g *Genesis, m GenesisMiner) {
+	store := AsStore(d.st)
+	params := actors.MinerParams{
+		Owner:              g.ID(m.Owner),
+		Worker:             g.ID(m.Worker),
+		Peer:               abi_spec.PeerID("chain-validation/" + m.Name),
+		SealProofType:      m.SealProofType,
+		ProvingPeriodStart: GenesisProvingPeriodStart,
+	}
+
+	// the miner must exist before its deals can name it as provider, so install it without sectors first.
+	minerState, _, err := d.av.NewMinerState(store, params)
+	require.NoError(d.tb, err)
+	robust := genesisActorAddress(d.tb, m.Name)
+	_, minerID, err := d.st.CreateActor(d.av.Codes().Miner, robust, big_spec.Zero(), minerState)
+	require.NoError(d.tb, err)
+	g.add(m.Name, GenesisActor{Robust: robust, ID: minerID})
+
+	if m.PreSeals > 0 {
+		client := m.Owner
+		if m.DealClient != "" {
+			client = m.DealClient
+		}
+		duration := m.DealDuration
+		if duration == 0 {
+			duration = DefaultGenesisDealDuration
+		}
+		ssize, err := m.SealProofType.SectorSize()
+		require.NoError(d.tb, err)
+		preseals := make([]*types.PreSeal, m.PreSeals)
+		for i := range preseals {
+			preseals[i] = g.Sectors.NewPreSealedSector(minerID, g.ID(client), m.SealProofType, ssize, 0, duration)
+		}
+
+		market, err := d.st.Actor(builtin_spec.StorageMarketActorAddr)
+		require.NoError(d.tb, err)
+		marketState, dealIDs, err := d.av.ActivateGenesisDeals(store, market.Head(), preseals)
+		require.NoError(d.tb, err)
+		_, err = d.st.SetActorState(builtin_spec.StorageMarketActorAddr, market.Balance(), marketState)
+		require.NoError(d.tb, err)
+
+		params.PreSeals = preseals
+		params.DealIDs = dealIDs
+	}
+
+	minerState, minerPower, err := d.av.NewMinerState(store, params)
+	require.NoError(d.tb, err)
+	_, err = d.st.SetActorState(minerID, big_spec.Zero(), minerState)
+	require.NoError(d.tb, err)
+
+	power, err := d.st.Actor(builtin_spec.StoragePowerActorAddr)
+	require.NoError(d.tb, err)
+	powerState, err := d.av.AddMinerToPower(store, power.Head(), minerID, minerPower)
+	require.NoError(d.tb, err)
+	power, err = d.st.SetActorState(builtin_spec.StoragePowerActorAddr, power.Balance(), powerState)
+	require.NoError(d.tb, err)
+
+	// only miners with sectors have deadlines to prove, so only they are enrolled in the power actor's cron.
+	if m.PreSeals > 0 {
+		powerState, err = d.av.EnrollProvingCron(store, power.Head(), minerID, params.ProvingPeriodStart)
+		require.NoError(d.tb, err)
+		_, err = d.st.SetActorState(builtin_spec.StoragePowerActorAddr, power.Balance(), powerState)
+		require.NoError(d.tb, err)
+	}
+}
@@ -0,0 +1,90 @@
+package drivers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// preState holds what the invariants checked after a message or tipset need from the state before it.
+type preState struct {
+	balances map[address.Address]abi_spec.TokenAmount
+}
+
+// capturePreState returns the state invariants are checked against after the next message or tipset, or nil when
+// the validation config disables invariant checks.
+func (td *TestDriver) capturePreState() *preState {
+	if !state.ValidateInvariants(td.Config) {
+		return nil
+	}
+	return &preState{balances: td.balances()}
+}
+
+// checkInvariants asserts the invariants that must hold after applying a message or tipset, `what` describing it in
+// failure messages. It does nothing when `pre` is nil.
+func (td *TestDriver) checkInvariants(pre *preState, what string) {
+	if pre == nil {
+		return
+	}
+	td.checkTokenConservation(pre, what)
+	td.checkBuiltinActorInvariants(what)
+}
+
+// balances returns the balance of every actor in the state tree, keyed by ID address.
+func (td *TestDriver) balances() map[address.Address]abi_spec.TokenAmount {
+	balances := make(map[address.Address]abi_spec.TokenAmount)
+	err := state.ForEachActor(td.State(), func(addr address.Address, actr state.Actor) error {
+		balances[addr] = actr.Balance()
+		return nil
+	})
+	require.NoError(td.T, err)
+	return balances
+}
+
+// checkTokenConservation asserts the sum of the balances of all actors is unchanged by a message or tipset, which only
+// moves tokens between actors. The sum is not checked against TotalNetworkBalance: tests fund the accounts they
+// install directly, minting tokens outside of it.
+func (td *TestDriver) checkTokenConservation(pre *preState, what string) {
+	post := td.balances()
+	before, after := totalBalance(pre.balances), totalBalance(post)
+	if before.Equals(after) {
+		return
+	}
+
+	var changes []string
+	for addr, bal := range post {
+		prev, ok := pre.balances[addr]
+		if !ok {
+			prev = big_spec.Zero()
+		}
+		if delta := big_spec.Sub(bal, prev); !delta.IsZero() {
+			changes = append(changes, fmt.Sprintf("%s: %s", addr, delta))
+		}
+	}
+	for addr, prev := range pre.balances {
+		if _, ok := post[addr]; !ok && !prev.IsZero() {
+			changes = append(changes, fmt.Sprintf("%s (deleted): %s", addr, prev.Neg()))
+		}
+	}
+	sort.Strings(changes)
+
+	assert.Fail(td.T, "tokens not conserved",
+		"%s changed the total balance of all actors by %s, from %s to %s. Balance changes:\n%s",
+		what, big_spec.Sub(after, before), before, after, strings.Join(changes, "\n"))
+}
+
+func totalBalance(balances map[address.Address]abi_spec.TokenAmount) abi_spec.TokenAmount {
+	total := big_spec.Zero()
+	for _, bal := range balances {
+		total = big_spec.Add(total, bal)
+	}
+	return total
+}
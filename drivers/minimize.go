@@ -0,0 +1,295 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// ReplayStep is a message or tipset applied by a driver while recording. Exactly one of Message, SignedMessage and
+// Blocks is set.
+type ReplayStep struct {
+	Epoch abi_spec.ChainEpoch
+	Miner address.Address
+
+	Message       *types.Message
+	SignedMessage *types.SignedMessage
+	Blocks        []types.BlockMessagesInfo
+}
+
+func (s ReplayStep) String() string {
+	switch {
+	case s.Message != nil:
+		return fmt.Sprintf("message at epoch %d from %s to %s (method %d, nonce %d, value %s)",
+			s.Epoch, s.Message.From, s.Message.To, s.Message.Method, s.Message.CallSeqNum, s.Message.Value)
+	case s.SignedMessage != nil:
+		msg := s.SignedMessage.Message
+		return fmt.Sprintf("signed message at epoch %d from %s to %s (method %d, nonce %d, value %s)",
+			s.Epoch, msg.From, msg.To, msg.Method, msg.CallSeqNum, msg.Value)
+	default:
+		count := 0
+		for _, blk := range s.Blocks {
+			count += len(blk.BLSMessages) + len(blk.SECPMessages)
+		}
+		return fmt.Sprintf("tipset at epoch %d of %d blocks holding %d messages", s.Epoch, len(s.Blocks), count)
+	}
+}
+
+// Recording is a sequence of messages and tipsets applied by a driver from a state, which Minimize replays.
+type Recording struct {
+	// PreState is the state the sequence was applied to, as a CAR file written by StateDriver.ExportState.
+	PreState []byte
+	Steps    []ReplayStep
+}
+
+// StartRecording records the messages and tipsets the driver applies from now on, starting from the current state.
+// Recording requires exporting the state.
+func (td *TestDriver) StartRecording() {
+	var buf bytes.Buffer
+	td.ExportState(&buf)
+	td.recording = &Recording{PreState: buf.Bytes()}
+}
+
+// Recording returns what was recorded since StartRecording, nil if it was not called.
+func (td *TestDriver) Recording() *Recording {
+	return td.recording
+}
+
+func (td *TestDriver) record(step ReplayStep) {
+	if td.recording != nil {
+		td.recording.Steps = append(td.recording.Steps, step)
+	}
+}
+
+// StartRecording records the messages and tipsets applied to both implementations from now on, starting from their
+// current state.
+func (dd *DifferentialDriver) StartRecording() {
+	dd.A.StartRecording()
+}
+
+// Recording returns what was recorded since StartRecording, nil if it was not called.
+func (dd *DifferentialDriver) Recording() *Recording {
+	return dd.A.Recording()
+}
+
+// Minimize searches the steps of `rec` for a minimal subsequence that still reproduces a failure, by delta debugging:
+// it replays ever smaller subsequences, keeping those that reproduce it. Each replay starts from the pre-state of the
+// recording in a new driver, built from the factory with `configure`, which must select the network version of the
+// recording.
+//
+// A replay reproduces the failure if it fails, e.g. by a panic of the implementation or a violated invariant, and,
+// when `factoryB` is not nil, if the implementations of the two factories return different receipts or state roots
+// for a step. Failures of replays do not fail `t`. An error is returned when the whole recording does not reproduce
+// the failure.
+func Minimize(t testing.TB, rec *Recording, factoryA, factoryB state.Factories, configure ...func(*TestDriverBuilder)) ([]ReplayStep, error) {
+	reproduces := func(steps []ReplayStep) (bool, error) {
+		a, err := replay(t, factoryA, configure, rec.PreState, steps)
+		if err != nil {
+			return false, err
+		}
+		if a.failed() || factoryB == nil {
+			return a.failed(), nil
+		}
+		b, err := replay(t, factoryB, configure, rec.PreState, steps)
+		if err != nil {
+			return false, err
+		}
+		return b.failed() || a.diverges(b), nil
+	}
+
+	steps, err := minimizeSteps(rec.Steps, reproduces)
+	if err != nil {
+		return nil, err
+	}
+	t.Logf("minimised %d recorded steps to %d", len(rec.Steps), len(steps))
+	return steps, nil
+}
+
+// minimizeSteps returns a minimal subsequence of `steps` for which `reproduces` is true, found by delta debugging.
+func minimizeSteps(steps []ReplayStep, reproduces func([]ReplayStep) (bool, error)) ([]ReplayStep, error) {
+	if ok, err := reproduces(steps); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, xerrors.Errorf("replaying the %d recorded steps does not reproduce the failure", len(steps))
+	}
+
+	n := 2
+	for len(steps) >= 2 {
+		chunks := splitSteps(steps, n)
+		reduced := false
+		// a single chunk reproducing the failure is the largest reduction, then the complement of a chunk.
+		for i := 0; i < len(chunks) && !reduced; i++ {
+			ok, err := reproduces(chunks[i])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				steps, n, reduced = chunks[i], 2, true
+			}
+		}
+		for i := 0; i < len(chunks) && !reduced && n > 2; i++ {
+			var complement []ReplayStep
+			for j, chunk := range chunks {
+				if j != i {
+					complement = append(complement, chunk...)
+				}
+			}
+			ok, err := reproduces(complement)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				steps, n, reduced = complement, n-1, true
+			}
+		}
+		if !reduced {
+			if n >= len(steps) {
+				break
+			}
+			n *= 2
+			if n > len(steps) {
+				n = len(steps)
+			}
+		}
+	}
+	return steps, nil
+}
+
+// splitSteps splits `steps` into `n` chunks of about the same length.
+func splitSteps(steps []ReplayStep, n int) [][]ReplayStep {
+	var chunks [][]ReplayStep
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + (len(steps)-start)/(n-i)
+		chunks = append(chunks, steps[start:end])
+		start = end
+	}
+	return chunks
+}
+
+// replayResult holds the results of replaying steps, up to the step that ended the replay if it was ended early.
+type replayResult struct {
+	t        *replayT
+	receipts [][]types.MessageReceipt
+	roots    []cid.Cid
+}
+
+func (r *replayResult) failed() bool {
+	return r.t.Failed()
+}
+
+// diverges returns true if the receipts or the state root of any step replayed by both differ.
+func (r *replayResult) diverges(o *replayResult) bool {
+	if len(r.roots) != len(o.roots) {
+		return true
+	}
+	for i := range r.roots {
+		if !r.roots[i].Equals(o.roots[i]) || len(r.receipts[i]) != len(o.receipts[i]) {
+			return true
+		}
+		for j, a := range r.receipts[i] {
+			b := o.receipts[i][j]
+			if a.ExitCode != b.ExitCode || a.GasUsed != b.GasUsed || !bytes.Equal(a.ReturnValue, b.ReturnValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replay applies `steps` from `preState` in a new driver, on a goroutine of its own so that failures can end it
+// without ending the test. An error is returned when the replay is skipped, e.g. because the implementation cannot
+// import the pre-state.
+func replay(t testing.TB, factory state.Factories, configure []func(*TestDriverBuilder), preState []byte, steps []ReplayStep) (*replayResult, error) {
+	res := &replayResult{t: &replayT{TB: t}}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b := NewBuilder(context.Background(), factory)
+		for _, c := range configure {
+			c(b)
+		}
+		td := b.Build(res.t)
+		td.ImportState(bytes.NewReader(preState))
+
+		for _, step := range steps {
+			td.ExeCtx.Epoch = step.Epoch
+			td.ExeCtx.Miner = step.Miner
+			var receipts []types.MessageReceipt
+			switch {
+			case step.Message != nil:
+				receipts = append(receipts, td.applyMessage(step.Message).Receipt)
+			case step.SignedMessage != nil:
+				receipts = append(receipts, td.applySignedMessage(step.SignedMessage).Receipt)
+			default:
+				receipts = td.applyTipSet(step.Blocks).Receipts
+			}
+			if td.lastPanic != nil {
+				return
+			}
+			res.receipts = append(res.receipts, receipts)
+			res.roots = append(res.roots, td.State().Root())
+		}
+	}()
+	<-done
+	if res.t.skipped {
+		return nil, xerrors.Errorf("replay skipped: %s", res.t.skipReason)
+	}
+	return res, nil
+}
+
+// replayT collects the failures of a replay instead of reporting them to the test. Ending the replay exits the
+// goroutine running it.
+type replayT struct {
+	testing.TB
+	failed     bool
+	skipped    bool
+	skipReason string
+}
+
+func (r *replayT) Helper()                                   {}
+func (r *replayT) Log(args ...interface{})                   {}
+func (r *replayT) Logf(format string, args ...interface{})   {}
+func (r *replayT) Error(args ...interface{})                 { r.Fail() }
+func (r *replayT) Errorf(format string, args ...interface{}) { r.Fail() }
+func (r *replayT) Fail()                                     { r.failed = true }
+func (r *replayT) Failed() bool                              { return r.failed }
+func (r *replayT) Skipped() bool                             { return r.skipped }
+
+func (r *replayT) FailNow() {
+	r.Fail()
+	runtime.Goexit()
+}
+
+func (r *replayT) Fatal(args ...interface{}) {
+	r.FailNow()
+}
+
+func (r *replayT) Fatalf(format string, args ...interface{}) {
+	r.FailNow()
+}
+
+func (r *replayT) Skip(args ...interface{}) {
+	r.skipReason = fmt.Sprint(args...)
+	r.SkipNow()
+}
+
+func (r *replayT) Skipf(format string, args ...interface{}) {
+	r.skipReason = fmt.Sprintf(format, args...)
+	r.SkipNow()
+}
+
+func (r *replayT) SkipNow() {
+	r.skipped = true
+	runtime.Goexit()
+}
@@ -0,0 +1,148 @@
+package drivers
+
+import (
+	"errors"
+	"testing"
+
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// stepsAt returns steps at epochs `epochs`, standing for the messages applied at them.
+func stepsAt(epochs ...abi_spec.ChainEpoch) []ReplayStep {
+	steps := make([]ReplayStep, len(epochs))
+	for i, e := range epochs {
+		steps[i] = ReplayStep{Epoch: e}
+	}
+	return steps
+}
+
+func epochRange(n int) []abi_spec.ChainEpoch {
+	epochs := make([]abi_spec.ChainEpoch, n)
+	for i := range epochs {
+		epochs[i] = abi_spec.ChainEpoch(i)
+	}
+	return epochs
+}
+
+func TestSplitSteps(t *testing.T) {
+	for _, tc := range []struct {
+		steps   int
+		n       int
+		lengths []int
+	}{
+		{steps: 10, n: 2, lengths: []int{5, 5}},
+		{steps: 10, n: 3, lengths: []int{3, 3, 4}},
+		{steps: 7, n: 4, lengths: []int{1, 2, 2, 2}},
+		{steps: 3, n: 2, lengths: []int{1, 2}},
+		{steps: 5, n: 5, lengths: []int{1, 1, 1, 1, 1}},
+	} {
+		steps := stepsAt(epochRange(tc.steps)...)
+		chunks := splitSteps(steps, tc.n)
+
+		var lengths []int
+		var joined []ReplayStep
+		for _, chunk := range chunks {
+			lengths = append(lengths, len(chunk))
+			joined = append(joined, chunk...)
+		}
+		assert.Equal(t, tc.lengths, lengths, "%d steps in %d chunks", tc.steps, tc.n)
+		assert.Equal(t, steps, joined, "%d steps in %d chunks", tc.steps, tc.n)
+	}
+}
+
+func TestMinimizeSteps(t *testing.T) {
+	errReplay := errors.New("replay skipped")
+
+	for _, tc := range []struct {
+		desc  string
+		steps int
+		// culprits are the epochs of the steps that must all be replayed to reproduce the failure.
+		culprits []abi_spec.ChainEpoch
+		// skipAfter makes replays fail with errReplay after that many, when non-zero.
+		skipAfter     int
+		notReproduced bool
+	}{
+		{desc: "single step", steps: 1, culprits: []abi_spec.ChainEpoch{0}},
+		{desc: "one culprit", steps: 8, culprits: []abi_spec.ChainEpoch{5}},
+		{desc: "first and last", steps: 9, culprits: []abi_spec.ChainEpoch{0, 8}},
+		{desc: "apart", steps: 16, culprits: []abi_spec.ChainEpoch{1, 6, 13}},
+		{desc: "adjacent", steps: 10, culprits: []abi_spec.ChainEpoch{2, 3, 4}},
+		{desc: "every step", steps: 4, culprits: []abi_spec.ChainEpoch{0, 1, 2, 3}},
+		{desc: "not reproduced", steps: 4, culprits: []abi_spec.ChainEpoch{7}, notReproduced: true},
+		{desc: "replay error", steps: 8, culprits: []abi_spec.ChainEpoch{5}, skipAfter: 3},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			replays := 0
+			reproduces := func(steps []ReplayStep) (bool, error) {
+				replays++
+				if tc.skipAfter > 0 && replays > tc.skipAfter {
+					return false, errReplay
+				}
+				found := 0
+				for _, s := range steps {
+					for _, c := range tc.culprits {
+						if s.Epoch == c {
+							found++
+						}
+					}
+				}
+				return found == len(tc.culprits), nil
+			}
+
+			minimal, err := minimizeSteps(stepsAt(epochRange(tc.steps)...), reproduces)
+			switch {
+			case tc.skipAfter > 0:
+				assert.True(t, errors.Is(err, errReplay), "error %v", err)
+			case tc.notReproduced:
+				assert.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, stepsAt(tc.culprits...), minimal)
+			}
+		})
+	}
+}
+
+func TestReplayResultDiverges(t *testing.T) {
+	root := func(s string) cid.Cid {
+		c, err := cid.Prefix{Version: 1, Codec: cid.DagCBOR, MhType: multihash.SHA2_256, MhLength: -1}.Sum([]byte(s))
+		require.NoError(t, err)
+		return c
+	}
+	receipt := types.MessageReceipt{ExitCode: exitcode.Ok, ReturnValue: []byte{1}, GasUsed: 100}
+	base := &replayResult{
+		receipts: [][]types.MessageReceipt{{receipt}, {receipt, receipt}},
+		roots:    []cid.Cid{root("a"), root("b")},
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		mutate   func(r *replayResult)
+		diverges bool
+	}{
+		{desc: "same results", mutate: func(r *replayResult) {}},
+		{desc: "ended early", mutate: func(r *replayResult) { r.receipts, r.roots = r.receipts[:1], r.roots[:1] }, diverges: true},
+		{desc: "state root", mutate: func(r *replayResult) { r.roots[1] = root("c") }, diverges: true},
+		{desc: "receipt count", mutate: func(r *replayResult) { r.receipts[1] = r.receipts[1][:1] }, diverges: true},
+		{desc: "exit code", mutate: func(r *replayResult) { r.receipts[1][1].ExitCode = exitcode.ErrForbidden }, diverges: true},
+		{desc: "gas used", mutate: func(r *replayResult) { r.receipts[0][0].GasUsed++ }, diverges: true},
+		{desc: "return value", mutate: func(r *replayResult) { r.receipts[0][0].ReturnValue = []byte{2} }, diverges: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			other := &replayResult{roots: append([]cid.Cid(nil), base.roots...)}
+			for _, rs := range base.receipts {
+				other.receipts = append(other.receipts, append([]types.MessageReceipt(nil), rs...))
+			}
+			tc.mutate(other)
+			assert.Equal(t, tc.diverges, base.diverges(other))
+			assert.Equal(t, tc.diverges, other.diverges(base))
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package drivers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// ParamsMutation is a structured mutation of the CBOR encoded params of a message.
+type ParamsMutation int
+
+const (
+	// MutationTruncate cuts the params short.
+	MutationTruncate ParamsMutation = iota
+	// MutationFieldCount adds or removes a field from the count of a top level tuple, leaving the fields unchanged.
+	MutationFieldCount
+	// MutationMajorType changes the major type of the top level item.
+	MutationMajorType
+	// MutationHugeLength makes the top level item claim a length of 2^32.
+	MutationHugeLength
+	// MutationIndefiniteLength encodes a top level tuple with an indefinite length.
+	MutationIndefiniteLength
+	// MutationTrailingBytes appends bytes after the top level item.
+	MutationTrailingBytes
+	// MutationNonCanonical encodes the header of the top level item on more bytes than needed.
+	MutationNonCanonical
+	// MutationByteFlip flips a bit of a random byte.
+	MutationByteFlip
+)
+
+func (m ParamsMutation) String() string {
+	switch m {
+	case MutationTruncate:
+		return "Truncate"
+	case MutationFieldCount:
+		return "FieldCount"
+	case MutationMajorType:
+		return "MajorType"
+	case MutationHugeLength:
+		return "HugeLength"
+	case MutationIndefiniteLength:
+		return "IndefiniteLength"
+	case MutationTrailingBytes:
+		return "TrailingBytes"
+	case MutationNonCanonical:
+		return "NonCanonical"
+	case MutationByteFlip:
+		return "ByteFlip"
+	default:
+		return fmt.Sprintf("ParamsMutation(%d)", int(m))
+	}
+}
+
+// Malformed returns true if params mutated this way can never be decoded as the params of the method, so the message
+// must fail with ErrSerialization. Other mutations may be decoded by lenient decoders, or yield other valid params.
+func (m ParamsMutation) Malformed() bool {
+	switch m {
+	case MutationTruncate, MutationFieldCount, MutationMajorType, MutationHugeLength, MutationIndefiniteLength:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	cborMajBytes = 2
+	cborMajArray = 4
+	cborMajMap   = 5
+)
+
+// MutateParams applies a random mutation applicable to `params` to a copy of them.
+func MutateParams(rnd *rand.Rand, params []byte) (ParamsMutation, []byte) {
+	maj, val, hdrLen, ok := readCBORHeader(params)
+	candidates := []ParamsMutation{MutationTrailingBytes}
+	if len(params) > 0 {
+		candidates = append(candidates, MutationTruncate, MutationByteFlip)
+	}
+	if ok {
+		candidates = append(candidates, MutationNonCanonical)
+	}
+	// integers and simple values of another major type or value may still be valid params.
+	if ok && maj >= cborMajBytes && maj <= cborMajMap {
+		candidates = append(candidates, MutationMajorType, MutationHugeLength)
+		if maj == cborMajArray {
+			candidates = append(candidates, MutationFieldCount, MutationIndefiniteLength)
+		}
+	}
+	mutation := candidates[rnd.Intn(len(candidates))]
+
+	body := params[hdrLen:]
+	var out []byte
+	switch mutation {
+	case MutationTruncate:
+		out = append(out, params[:rnd.Intn(len(params))]...)
+	case MutationFieldCount:
+		count := val + 1
+		if val > 0 && rnd.Intn(2) == 0 {
+			count = val - 1
+		}
+		out = append(writeCBORHeader(maj, count), body...)
+	case MutationMajorType:
+		other := byte(rnd.Intn(7))
+		if other >= maj {
+			other++
+		}
+		out = append(writeCBORHeader(other, val), body...)
+	case MutationHugeLength:
+		out = append(writeCBORHeader(maj, 1<<32), body...)
+	case MutationIndefiniteLength:
+		out = append([]byte{cborMajArray<<5 | 31}, body...)
+		out = append(out, 0xff)
+	case MutationTrailingBytes:
+		trailing := make([]byte, 1+rnd.Intn(8))
+		rnd.Read(trailing) // nolint: errcheck
+		out = append(append(out, params...), trailing...)
+	case MutationNonCanonical:
+		hdr := make([]byte, 9)
+		hdr[0] = maj<<5 | 27
+		binary.BigEndian.PutUint64(hdr[1:], val)
+		out = append(hdr, body...)
+	case MutationByteFlip:
+		out = append(out, params...)
+		out[rnd.Intn(len(out))] ^= 1 << uint(rnd.Intn(8))
+	}
+	return mutation, out
+}
+
+// readCBORHeader decodes the header of the CBOR item at the start of `b`, returning false if it is missing or of
+// indefinite length.
+func readCBORHeader(b []byte) (maj byte, val uint64, n int, ok bool) {
+	if len(b) == 0 {
+		return 0, 0, 0, false
+	}
+	maj, info := b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return maj, uint64(info), 1, true
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(b) < 1+size {
+			return 0, 0, 0, false
+		}
+		var buf [8]byte
+		copy(buf[8-size:], b[1:1+size])
+		return maj, binary.BigEndian.Uint64(buf[:]), 1 + size, true
+	default:
+		return 0, 0, 0, false
+	}
+}
+
+// writeCBORHeader returns the minimal encoding of a header of major type `maj` with value `val`.
+func writeCBORHeader(maj byte, val uint64) []byte {
+	switch {
+	case val < 24:
+		return []byte{maj<<5 | byte(val)}
+	case val <= 0xff:
+		return []byte{maj<<5 | 24, byte(val)}
+	case val <= 0xffff:
+		hdr := []byte{maj<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(hdr[1:], uint16(val))
+		return hdr
+	case val <= 0xffffffff:
+		hdr := []byte{maj<<5 | 26, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(hdr[1:], uint32(val))
+		return hdr
+	default:
+		hdr := make([]byte, 9)
+		hdr[0] = maj<<5 | 27
+		binary.BigEndian.PutUint64(hdr[1:], val)
+		return hdr
+	}
+}
+
+// FuzzMessageParams applies `n` random mutations of the params of a message, each as a subtest against two drivers
+// built from `factory` with `configure`. `produce` sets up the state of the drivers and returns the message to
+// mutate; it is called once per mutation. The mutated message must be applied identically by both drivers, gas
+// included, and fail with ErrSerialization when the mutation leaves the params malformed.
+func FuzzMessageParams(t *testing.T, factory state.Factories, seed int64, n int, configure func(*TestDriverBuilder), produce func(dd *DifferentialDriver) *types.Message) {
+	rnd := rand.New(rand.NewSource(seed))
+	for i := 0; i < n; i++ {
+		mutationSeed := rnd.Int63()
+		t.Run(fmt.Sprintf("mutation%d", i), func(t *testing.T) {
+			dd := NewDifferentialDriver(t, factory, factory, configure)
+			msg := *produce(dd)
+			mutation, params := MutateParams(rand.New(rand.NewSource(mutationSeed)), msg.Params)
+			t.Logf("%s mutation of params %x: %x", mutation, msg.Params, params)
+			msg.Params = params
+
+			result, _ := dd.ApplyMessage(&msg)
+			if mutation.Malformed() && dd.A.Config.ValidateExitCode() && dd.A.lastPanic == nil {
+				assert.Equal(t, exitcode.ErrSerialization, result.Receipt.ExitCode, "%s mutation: Expected ExitCode: %s Actual ExitCode: %s", mutation, exitcode.ErrSerialization.Error(), result.Receipt.ExitCode.Error())
+			}
+		})
+	}
+}
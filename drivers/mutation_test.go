@@ -0,0 +1,156 @@
+package drivers
+
+import (
+	"bytes"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCBORHeader(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		b    []byte
+		maj  byte
+		val  uint64
+		n    int
+		ok   bool
+	}{
+		{desc: "empty"},
+		{desc: "small integer", b: []byte{0x17}, maj: 0, val: 23, n: 1, ok: true},
+		{desc: "one byte value", b: []byte{0x18, 0xff}, maj: 0, val: 0xff, n: 2, ok: true},
+		{desc: "two byte value", b: []byte{0x59, 0x01, 0x00}, maj: cborMajBytes, val: 0x100, n: 3, ok: true},
+		{desc: "four byte value", b: []byte{0x9a, 0, 1, 0, 0}, maj: cborMajArray, val: 0x10000, n: 5, ok: true},
+		{desc: "eight byte value", b: []byte{0xbb, 0, 0, 0, 1, 0, 0, 0, 0, 0xa0}, maj: cborMajMap, val: 1 << 32, n: 9, ok: true},
+		{desc: "followed by body", b: []byte{0x82, 0x01, 0x02}, maj: cborMajArray, val: 2, n: 1, ok: true},
+		{desc: "missing value bytes", b: []byte{0x19, 0x01}},
+		{desc: "reserved info", b: []byte{0x1c}},
+		{desc: "indefinite length", b: []byte{0x9f, 0xff}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			maj, val, n, ok := readCBORHeader(tc.b)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.maj, maj)
+			assert.Equal(t, tc.val, val)
+			assert.Equal(t, tc.n, n)
+		})
+	}
+}
+
+func TestWriteCBORHeaderIsMinimal(t *testing.T) {
+	for _, tc := range []struct {
+		val uint64
+		n   int
+	}{
+		{0, 1}, {23, 1}, {24, 2}, {0xff, 2}, {0x100, 3}, {0xffff, 3}, {0x10000, 5}, {0xffffffff, 5}, {1 << 32, 9},
+	} {
+		hdr := writeCBORHeader(cborMajArray, tc.val)
+		assert.Len(t, hdr, tc.n, "value %d", tc.val)
+		maj, val, n, ok := readCBORHeader(hdr)
+		require.True(t, ok, "value %d", tc.val)
+		assert.Equal(t, byte(cborMajArray), maj)
+		assert.Equal(t, tc.val, val)
+		assert.Equal(t, tc.n, n)
+	}
+}
+
+func TestMutateParams(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		params []byte
+		// mutations are those applicable to the params.
+		mutations []ParamsMutation
+	}{
+		{desc: "no params", params: nil, mutations: []ParamsMutation{MutationTrailingBytes}},
+		{
+			desc:      "integer",
+			params:    []byte{0x18, 0x2a},
+			mutations: []ParamsMutation{MutationTrailingBytes, MutationTruncate, MutationByteFlip, MutationNonCanonical},
+		},
+		{
+			desc:   "bytes",
+			params: []byte{0x43, 1, 2, 3},
+			mutations: []ParamsMutation{MutationTrailingBytes, MutationTruncate, MutationByteFlip, MutationNonCanonical,
+				MutationMajorType, MutationHugeLength},
+		},
+		{
+			desc:   "tuple",
+			params: []byte{0x83, 0x01, 0x40, 0xf6},
+			mutations: []ParamsMutation{MutationTrailingBytes, MutationTruncate, MutationByteFlip, MutationNonCanonical,
+				MutationMajorType, MutationHugeLength, MutationFieldCount, MutationIndefiniteLength},
+		},
+		{
+			desc:      "indefinite length tuple",
+			params:    []byte{0x9f, 0x01, 0xff},
+			mutations: []ParamsMutation{MutationTrailingBytes, MutationTruncate, MutationByteFlip},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			original := append([]byte(nil), tc.params...)
+			maj, val, hdrLen, _ := readCBORHeader(tc.params)
+			body := tc.params[hdrLen:]
+
+			seen := make(map[ParamsMutation]bool)
+			for seed := int64(0); seed < 200; seed++ {
+				mutation, out := MutateParams(rand.New(rand.NewSource(seed)), tc.params)
+				require.Contains(t, tc.mutations, mutation)
+				seen[mutation] = true
+				require.Equal(t, original, tc.params, "%s mutation modified the params", mutation)
+
+				outMaj, outVal, outHdrLen, ok := readCBORHeader(out)
+				switch mutation {
+				case MutationTruncate:
+					assert.Less(t, len(out), len(tc.params))
+					assert.True(t, bytes.HasPrefix(tc.params, out), "%x is not a prefix of the params", out)
+				case MutationFieldCount:
+					require.True(t, ok)
+					assert.Equal(t, maj, outMaj)
+					assert.True(t, outVal == val+1 || outVal == val-1, "field count %d of %d", outVal, val)
+					assert.Equal(t, body, out[outHdrLen:])
+				case MutationMajorType:
+					require.True(t, ok)
+					assert.NotEqual(t, maj, outMaj)
+					assert.Less(t, outMaj, byte(8))
+					assert.Equal(t, val, outVal)
+					assert.Equal(t, body, out[outHdrLen:])
+				case MutationHugeLength:
+					require.True(t, ok)
+					assert.Equal(t, maj, outMaj)
+					assert.Equal(t, uint64(1<<32), outVal)
+					assert.Equal(t, body, out[outHdrLen:])
+				case MutationIndefiniteLength:
+					assert.Equal(t, append(append([]byte{0x9f}, body...), 0xff), out)
+				case MutationTrailingBytes:
+					assert.True(t, bytes.HasPrefix(out, tc.params), "%x does not start with the params", out)
+					assert.GreaterOrEqual(t, len(out)-len(tc.params), 1)
+					assert.LessOrEqual(t, len(out)-len(tc.params), 8)
+				case MutationNonCanonical:
+					require.True(t, ok)
+					assert.Equal(t, 9, outHdrLen)
+					assert.Equal(t, maj, outMaj)
+					assert.Equal(t, val, outVal)
+					assert.Equal(t, body, out[outHdrLen:])
+				case MutationByteFlip:
+					require.Len(t, out, len(tc.params))
+					flipped := 0
+					for i := range out {
+						flipped += bits.OnesCount8(out[i] ^ tc.params[i])
+					}
+					assert.Equal(t, 1, flipped)
+				}
+			}
+			// every applicable mutation is eventually picked.
+			for _, m := range tc.mutations {
+				assert.True(t, seen[m], "%s mutation never picked", m)
+			}
+		})
+	}
+}
+
+func TestParamsMutationString(t *testing.T) {
+	assert.Equal(t, "IndefiniteLength", MutationIndefiniteLength.String())
+	assert.Equal(t, "ParamsMutation(42)", ParamsMutation(42).String())
+}
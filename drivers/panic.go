@@ -0,0 +1,80 @@
+package drivers
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/debug"
+
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// PanicFailurePrefix starts the failure reported for a panic of the implementation, setting it apart from the
+// failures of results that do not match expectations.
+const PanicFailurePrefix = "IMPLEMENTATION PANIC"
+
+// ImplementationPanic is a panic raised by the implementation while applying a message or tipset.
+type ImplementationPanic struct {
+	// What describes what was being applied, e.g. "message".
+	What  string
+	Epoch abi_spec.ChainEpoch
+	// Value is the value the implementation panicked with.
+	Value interface{}
+	// Stack is the stack of the goroutine that panicked, at the point of the panic.
+	Stack []byte
+}
+
+func (p *ImplementationPanic) Error() string {
+	return fmt.Sprintf("panicked applying %s at epoch %d: %v", p.What, p.Epoch, p.Value)
+}
+
+// applyGuarded calls `apply`, which hands a message or tipset described by `what` to the implementation, recovering a
+// panic of the implementation. Only the call to the implementation belongs in `apply`, so that failures of the
+// driver's own checks are not mistaken for panics of the implementation. The panic is reported with its stack and
+// ends the test, unless the validation config tolerates panics: the test then continues against a fresh VM holding
+// the state from before `apply`, and the panic is returned.
+func (td *TestDriver) applyGuarded(what string, apply func()) (p *ImplementationPanic) {
+	// the blocks of the state before `apply` stay in the store, so only its root is kept until a panic needs them.
+	preRoot := td.State().Root()
+	td.lastPanic = nil
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		p = &ImplementationPanic{What: what, Epoch: td.ExeCtx.Epoch, Value: r, Stack: debug.Stack()}
+		td.lastPanic = p
+		td.Panics = append(td.Panics, p)
+		td.T.Errorf("%s: %s\n%s", PanicFailurePrefix, p, p.Stack)
+		if !state.TolerateImplementationPanics(td.Config) {
+			td.T.FailNow()
+		}
+		td.freshVM(preRoot)
+	}()
+	apply()
+	return nil
+}
+
+// freshVM replaces the VM of the driver, which a panic may have left inconsistent, with a new one holding the state
+// rooted at `preRoot`. The new VM need not share the store of the old one, so the state is exported from the old
+// store and imported into the new one before it adopts the root. The test is skipped, still failed by the panic, if
+// the wrapper cannot adopt a root.
+func (td *TestDriver) freshVM(preRoot cid.Cid) {
+	if _, ok := td.State().(state.RootSetter); !ok {
+		td.T.Skipf("not continuing after the panic: the state wrapper does not implement state.RootSetter, so a fresh VM cannot adopt the state from before it")
+	}
+	var preState bytes.Buffer
+	if err := state.ExportCAR(td.State(), preRoot, &preState); err != nil {
+		td.T.Fatalf("exporting the state from before the panic: %v", err)
+	}
+	td.State().NewVM()
+	root, err := state.ImportCAR(td.State(), &preState)
+	if err != nil {
+		td.T.Fatalf("importing the state from before the panic into a fresh VM: %v", err)
+	}
+	if err := state.SetRoot(td.State(), root); err != nil {
+		td.T.Fatalf("restoring state root %s in a fresh VM after a panic: %v", root, err)
+	}
+}
@@ -0,0 +1,198 @@
+package drivers
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// memVM is a state wrapper whose store holds DAG-CBOR blocks in memory. A new VM starts with an empty store, as VMs
+// need not share their stores, and can only adopt a root held by its store.
+type memVM struct {
+	blocks map[cid.Cid][]byte
+	root   cid.Cid
+	newVMs int
+}
+
+func newMemVM() *memVM {
+	return &memVM{blocks: make(map[cid.Cid][]byte)}
+}
+
+func (vm *memVM) NewVM() {
+	vm.blocks = make(map[cid.Cid][]byte)
+	vm.root = cid.Undef
+	vm.newVMs++
+}
+
+func (vm *memVM) Root() cid.Cid {
+	return vm.root
+}
+
+func (vm *memVM) SetRoot(root cid.Cid) error {
+	if _, ok := vm.blocks[root]; !ok {
+		return xerrors.Errorf("root %s not in store", root)
+	}
+	vm.root = root
+	return nil
+}
+
+func (vm *memVM) StoreGet(key cid.Cid, out runtime.CBORUnmarshaler) error {
+	data, ok := vm.blocks[key]
+	if !ok {
+		return xerrors.Errorf("block %s not found", key)
+	}
+	return out.UnmarshalCBOR(bytes.NewReader(data))
+}
+
+func (vm *memVM) StorePut(value runtime.CBORMarshaler) (cid.Cid, error) {
+	var buf bytes.Buffer
+	if err := value.MarshalCBOR(&buf); err != nil {
+		return cid.Undef, err
+	}
+	c, err := cid.Prefix{Version: 1, Codec: cid.DagCBOR, MhType: multihash.SHA2_256, MhLength: -1}.Sum(buf.Bytes())
+	if err != nil {
+		return cid.Undef, err
+	}
+	vm.blocks[c] = buf.Bytes()
+	return c, nil
+}
+
+func (vm *memVM) Actor(address.Address) (state.Actor, error) {
+	return nil, state.ErrUnsupported
+}
+
+func (vm *memVM) SetActorState(address.Address, abi_spec.TokenAmount, runtime.CBORMarshaler) (state.Actor, error) {
+	return nil, state.ErrUnsupported
+}
+
+func (vm *memVM) CreateActor(cid.Cid, address.Address, abi_spec.TokenAmount, runtime.CBORMarshaler) (state.Actor, address.Address, error) {
+	return nil, address.Undef, state.ErrUnsupported
+}
+
+// mustPutLinks puts a DAG-CBOR array of `links` in the store of `vm`.
+func (vm *memVM) mustPutLinks(t *testing.T, links ...cid.Cid) cid.Cid {
+	var buf bytes.Buffer
+	require.NoError(t, cbg.WriteMajorTypeHeader(&buf, cbg.MajArray, uint64(len(links))))
+	for _, l := range links {
+		require.NoError(t, cbg.WriteCid(&buf, l))
+	}
+	c, err := vm.StorePut(rawCBOR(buf.Bytes()))
+	require.NoError(t, err)
+	return c
+}
+
+type rawCBOR []byte
+
+func (b rawCBOR) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write(b)
+	return err
+}
+
+type panicsConfig struct {
+	tolerate bool
+}
+
+func (c panicsConfig) ValidateGas() bool                  { return false }
+func (c panicsConfig) ValidateExitCode() bool             { return false }
+func (c panicsConfig) ValidateReturnValue() bool          { return false }
+func (c panicsConfig) ValidateStateRoot() bool            { return false }
+func (c panicsConfig) ValidateRewardsAndPenalties() bool  { return false }
+func (c panicsConfig) TolerateImplementationPanics() bool { return c.tolerate }
+
+// rootlessVM hides the RootSetter implementation of the wrapper it holds.
+type rootlessVM struct {
+	state.VMWrapper
+}
+
+func TestApplyGuarded(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		tolerate bool
+		panics   bool
+		// rootless hides the RootSetter implementation of the wrapper.
+		rootless bool
+		// freshVM is true if the driver continues against a fresh VM.
+		freshVM bool
+	}{
+		{desc: "no panic", tolerate: true},
+		{desc: "tolerated panic", tolerate: true, panics: true, freshVM: true},
+		{desc: "panic ends the test", tolerate: false, panics: true},
+		{desc: "tolerated panic without root setter skips the test", tolerate: true, panics: true, rootless: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			vm := newMemVM()
+			leaf := vm.mustPutLinks(t)
+			vm.root = vm.mustPutLinks(t, leaf, leaf)
+			preRoot := vm.root
+			preBlocks := make(map[cid.Cid][]byte)
+			for c, b := range vm.blocks {
+				preBlocks[c] = b
+			}
+
+			var st state.VMWrapper = vm
+			if tc.rootless {
+				st = rootlessVM{vm}
+			}
+			rt := &replayT{TB: t}
+			td := &TestDriver{
+				StateDriver: &StateDriver{tb: rt, st: st},
+				T:           rt,
+				ExeCtx:      &types.ExecutionContext{Epoch: 7},
+				Config:      panicsConfig{tolerate: tc.tolerate},
+			}
+
+			var p *ImplementationPanic
+			returned := false
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				p = td.applyGuarded("message", func() {
+					// the implementation leaves its store and root inconsistent before panicking.
+					vm.root = vm.mustPutLinks(t, preRoot)
+					if tc.panics {
+						panic("boom")
+					}
+				})
+				returned = true
+			}()
+			<-done
+
+			if !tc.panics {
+				assert.True(t, returned)
+				assert.Nil(t, p)
+				assert.False(t, rt.Failed())
+				assert.Zero(t, vm.newVMs)
+				return
+			}
+
+			assert.True(t, rt.Failed(), "the panic was not reported")
+			require.Len(t, td.Panics, 1)
+			assert.Equal(t, "boom", td.Panics[0].Value)
+			assert.Equal(t, abi_spec.ChainEpoch(7), td.Panics[0].Epoch)
+			assert.Equal(t, td.Panics[0], td.lastPanic)
+			assert.Equal(t, tc.freshVM, returned, "the test did not continue")
+			assert.Equal(t, tc.rootless, rt.Skipped())
+			if !tc.freshVM {
+				assert.Zero(t, vm.newVMs)
+				return
+			}
+			assert.Equal(t, td.Panics[0], p)
+			assert.Equal(t, 1, vm.newVMs)
+			assert.Equal(t, preRoot, vm.Root())
+			assert.Equal(t, preBlocks, vm.blocks, "the fresh VM does not hold exactly the state from before the panic")
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package drivers
+
+import (
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	puppet_spec "github.com/filecoin-project/specs-actors/actors/puppet"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// PuppetAddress is the address WithPuppetActor installs the puppet actor at, the ID before the burnt funds actor's.
+var PuppetAddress address.Address
+
+func init() {
+	var err error
+	PuppetAddress, err = address.NewIDAddress(builtin_spec.FirstNonSingletonActorId - 2)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// WithPuppetActor installs a puppet actor with no funds at PuppetAddress. The puppet sends whatever it is told to,
+// see PuppetScript.
+func (b *TestDriverBuilder) WithPuppetActor() *TestDriverBuilder {
+	return b.WithActorState(ActorState{
+		Addr:    PuppetAddress,
+		Balance: big_spec.Zero(),
+		Code:    puppet_spec.PuppetActorCodeID,
+		State:   &puppet_spec.State{},
+	})
+}
+
+// PuppetScript is a call relayed to its receiver through a chain of puppet actors, each telling the next what to send.
+// The first puppet receives the message of the script, the last makes the call. Scripts are built fluently:
+//
+//	// the puppet sends to itself twice, then sends `value` to bob.
+//	msg := td.Puppet().Recurse(2).Send(bob, value).Message(alice, chain.Nonce(0))
+//
+// The value of the call is forwarded by each puppet, starting with the message. A puppet does not abort when the send
+// it is told to make fails, it returns the exit code of the send, so the message of a script succeeds however deep
+// the failure; Returns decodes what each puppet returned.
+//
+// Instead of a call, the last puppet may be told to fail in one of the ways the puppet of the v0 actors exports:
+// FailParams, FailReturn and FailStateWrite have it call itself to send params, return a value or write a state that
+// cannot be encoded, the last aborting after the state was changed. Scripts cannot have a puppet abort with an exit
+// code of their choosing nor return a value of their own: the puppet does not export either, and no actor installed by
+// the driver does so on request. Such cases are limited to the codes and values of builtin actor methods.
+type PuppetScript struct {
+	td *TestDriver
+	// relays are the puppets the call passes through, in order.
+	relays []address.Address
+
+	to     address.Address
+	value  abi_spec.TokenAmount
+	method abi_spec.MethodNum
+	params []byte
+}
+
+// Puppet returns a script relayed by the puppet installed by WithPuppetActor.
+func (td *TestDriver) Puppet() *PuppetScript {
+	return td.PuppetAt(PuppetAddress)
+}
+
+// PuppetAt returns a script relayed by the puppet at `puppet`.
+func (td *TestDriver) PuppetAt(puppet address.Address) *PuppetScript {
+	return &PuppetScript{
+		td:     td,
+		relays: []address.Address{puppet},
+		to:     address.Undef,
+		value:  big_spec.Zero(),
+		method: builtin_spec.MethodSend,
+	}
+}
+
+// Through relays the call through `puppets` after those already in the script.
+func (s *PuppetScript) Through(puppets ...address.Address) *PuppetScript {
+	s.relays = append(s.relays, puppets...)
+	return s
+}
+
+// Recurse has the last puppet in the script send to itself `depth` times before the call.
+func (s *PuppetScript) Recurse(depth int) *PuppetScript {
+	last := s.relays[len(s.relays)-1]
+	for i := 0; i < depth; i++ {
+		s.relays = append(s.relays, last)
+	}
+	return s
+}
+
+// Send makes the call of the script a transfer of `value` to `to`.
+func (s *PuppetScript) Send(to address.Address, value abi_spec.TokenAmount) *PuppetScript {
+	return s.Call(to, builtin_spec.MethodSend, nil, value)
+}
+
+// Call makes the call of the script a call of `method` of `to` with `params`, nil for none, sending `value`.
+func (s *PuppetScript) Call(to address.Address, method abi_spec.MethodNum, params cbg.CBORMarshaler, value abi_spec.TokenAmount) *PuppetScript {
+	s.to, s.method, s.value = to, method, value
+	s.params = nil
+	if params != nil {
+		s.params = chain.MustSerialize(params)
+	}
+	return s
+}
+
+// FailParams has the last puppet in the script call itself to send params that cannot be encoded to `method` of
+// `to`, instead of the call.
+func (s *PuppetScript) FailParams(to address.Address, method abi_spec.MethodNum) *PuppetScript {
+	last := s.relays[len(s.relays)-1]
+	params := &puppet_spec.SendParams{To: to, Value: big_spec.Zero(), Method: method}
+	return s.Call(last, puppet_spec.MethodsPuppet.SendMarshalCBORFailure, params, big_spec.Zero())
+}
+
+// FailReturn has the last puppet in the script call itself to return a value that cannot be encoded, instead of the
+// call.
+func (s *PuppetScript) FailReturn() *PuppetScript {
+	last := s.relays[len(s.relays)-1]
+	return s.Call(last, puppet_spec.MethodsPuppet.ReturnMarshalCBORFailure, &adt.EmptyValue{}, big_spec.Zero())
+}
+
+// FailStateWrite has the last puppet in the script call itself to write a state that cannot be encoded, instead of
+// the call. The state is changed before the write aborts, so the change must be rolled back.
+func (s *PuppetScript) FailStateWrite() *PuppetScript {
+	last := s.relays[len(s.relays)-1]
+	return s.Call(last, puppet_spec.MethodsPuppet.RuntimeTransactionMarshalCBORFailure, &adt.EmptyValue{}, big_spec.Zero())
+}
+
+// Depth returns the number of puppets relaying the call.
+func (s *PuppetScript) Depth() int {
+	return len(s.relays)
+}
+
+// Params returns the params of the message to the first puppet.
+func (s *PuppetScript) Params() *puppet_spec.SendParams {
+	if s.to == address.Undef {
+		s.td.T.Fatal("puppet script has no call")
+	}
+	params := &puppet_spec.SendParams{To: s.to, Value: s.value, Method: s.method, Params: s.params}
+	for i := len(s.relays) - 1; i > 0; i-- {
+		params = &puppet_spec.SendParams{
+			To:     s.relays[i],
+			Value:  s.value,
+			Method: puppet_spec.MethodsPuppet.Send,
+			Params: chain.MustSerialize(params),
+		}
+	}
+	return params
+}
+
+// Message returns the message from `from` starting the script. The message sends the value of the call unless
+// overridden by `opts`.
+func (s *PuppetScript) Message(from address.Address, opts ...chain.MsgOpt) *types.Message {
+	opts = append([]chain.MsgOpt{chain.Value(s.value)}, opts...)
+	return s.td.MessageProducer.PuppetSend(from, s.relays[0], s.Params(), opts...)
+}
+
+// Apply applies the message from `from` starting the script, expecting it to succeed, and returns the result with
+// what each puppet returned.
+func (s *PuppetScript) Apply(from address.Address, opts ...chain.MsgOpt) (types.ApplyMessageResult, []*puppet_spec.SendReturn) {
+	result := s.td.ApplyMessage(s.Message(from, opts...))
+	require.Equal(s.td.T, exitcode.Ok, result.Receipt.ExitCode, "puppet script message failed")
+	return result, s.Returns(result.Receipt.ReturnValue)
+}
+
+// Returns decodes what each puppet of the script returned from `ret`, the return value of its message, outermost
+// first. A puppet returns what the next returned unless the send to it failed, so the returns end at the first puppet
+// whose send failed or, if none did, at the last, whose return holds the return value of the call.
+func (s *PuppetScript) Returns(ret []byte) []*puppet_spec.SendReturn {
+	var returns []*puppet_spec.SendReturn
+	for range s.relays {
+		r, err := chain.DecodePuppetSendReturn(ret)
+		require.NoError(s.td.T, err, "decoding return of puppet %d", len(returns))
+		returns = append(returns, r)
+		if r.Code != exitcode.Ok {
+			break
+		}
+		ret = r.Return
+	}
+	return returns
+}
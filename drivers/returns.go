@@ -0,0 +1,170 @@
+package drivers
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/stretchr/testify/assert"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// ApplyExpectReturn applies a message, expecting it to succeed and return `expected`. The return value is decoded
+// into the type of `expected`, a pointer, and compared field by field, so that a mismatch is reported as the fields
+// that differ rather than as the encoded values.
+func (td *TestDriver) ApplyExpectReturn(msg *types.Message, expected cbg.CBORUnmarshaler) types.ApplyMessageResult {
+	result := td.applyMessage(msg)
+	td.validateResult(result, ExpectedResult{ExitCode: exitcode.Ok, Return: expected})
+	td.validateState(msg, result)
+	return result
+}
+
+// WithBLSMessageAndReturn adds a BLS message, expecting it to succeed and return `expected` as ApplyExpectReturn does.
+func (bb *BlockBuilder) WithBLSMessageAndReturn(bm *types.Message, expected cbg.CBORUnmarshaler) *BlockBuilder {
+	bb.blsMsgs = append(bb.blsMsgs, bm)
+	bb.expectedResults = append(bb.expectedResults, ExpectedResult{ExitCode: exitcode.Ok, Return: expected})
+	return bb
+}
+
+// WithSECPMessageAndReturn adds a SECP message, expecting it to succeed and return `expected` as ApplyExpectReturn
+// does.
+func (bb *BlockBuilder) WithSECPMessageAndReturn(bm *types.Message, expected cbg.CBORUnmarshaler) *BlockBuilder {
+	bb.secpMsgs = append(bb.secpMsgs, bb.toSignedMessage(bm))
+	bb.expectedResults = append(bb.expectedResults, ExpectedResult{ExitCode: exitcode.Ok, Return: expected})
+	return bb
+}
+
+// validateReturnValue asserts `actual` is the return value of `expected`, prefixing failures with `prefix`.
+func (td *TestDriver) validateReturnValue(prefix string, expected ExpectedResult, actual []byte) {
+	if expected.Return == nil {
+		assert.Equal(td.T, expected.ReturnVal, actual, "%sExpected ReturnValue: %v Actual ReturnValue: %v", prefix, expected.ReturnVal, actual)
+		return
+	}
+
+	typ := reflect.TypeOf(expected.Return)
+	if typ.Kind() != reflect.Ptr {
+		td.T.Fatalf("%sexpected return value of type %s must be a pointer", prefix, typ)
+	}
+	decoded := reflect.New(typ.Elem()).Interface()
+	if err := chain.Deserialize(actual, decoded); err != nil {
+		assert.Fail(td.T, fmt.Sprintf("%sExpected ReturnValue of type %s, failed to decode %x: %v", prefix, typ, actual, err))
+		return
+	}
+	if diffs := diffValues("", reflect.ValueOf(expected.Return), reflect.ValueOf(decoded)); len(diffs) > 0 {
+		assert.Fail(td.T, fmt.Sprintf("%sReturnValue of type %s differs:\n\t%s", prefix, typ, strings.Join(diffs, "\n\t")))
+	}
+}
+
+// diffValues returns a line for each field at which `exp` and `act` differ, named by its path from the compared
+// values. Structs with exported fields, pointers, slices and arrays are compared by their elements, anything else as a
+// whole.
+func diffValues(path string, exp, act reflect.Value) []string {
+	if reflect.DeepEqual(exp.Interface(), act.Interface()) {
+		return nil
+	}
+
+	switch exp.Kind() {
+	case reflect.Ptr:
+		if exp.IsNil() || act.IsNil() {
+			return []string{diffLine(path, exp, act)}
+		}
+		return diffValues(path, exp.Elem(), act.Elem())
+	case reflect.Struct:
+		if !comparedByFields(exp) {
+			break
+		}
+		var diffs []string
+		for i := 0; i < exp.NumField(); i++ {
+			field := exp.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			diffs = append(diffs, diffValues(path+"."+field.Name, exp.Field(i), act.Field(i))...)
+		}
+		return diffs
+	case reflect.Slice, reflect.Array:
+		// a nil slice decodes as an empty one.
+		if exp.Len() == 0 && act.Len() == 0 {
+			return nil
+		}
+		if exp.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		var diffs []string
+		if exp.Len() != act.Len() {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %d elements, actual %d", pathName(path), exp.Len(), act.Len()))
+		}
+		for i := 0; i < exp.Len() && i < act.Len(); i++ {
+			diffs = append(diffs, diffValues(fmt.Sprintf("%s[%d]", path, i), exp.Index(i), act.Index(i))...)
+		}
+		return diffs
+	}
+
+	if encodedEqual(exp, act) {
+		return nil
+	}
+	return []string{diffLine(path, exp, act)}
+}
+
+// comparedByFields reports whether the struct `v` is compared field by field. Structs with no exported fields, such as
+// addresses and CIDs, and structs with a string form, such as big integers, are compared as a whole.
+func comparedByFields(v reflect.Value) bool {
+	if _, ok := v.Interface().(fmt.Stringer); ok {
+		return false
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// encodedEqual reports whether `exp` and `act` have the same CBOR encoding, e.g. bitfields holding the same bits in
+// different representations.
+func encodedEqual(exp, act reflect.Value) bool {
+	expEnc, ok := encode(exp)
+	if !ok {
+		return false
+	}
+	actEnc, ok := encode(act)
+	return ok && bytes.Equal(expEnc, actEnc)
+}
+
+func encode(v reflect.Value) ([]byte, bool) {
+	m, ok := v.Interface().(cbg.CBORMarshaler)
+	if !ok && v.CanAddr() {
+		m, ok = v.Addr().Interface().(cbg.CBORMarshaler)
+	}
+	if !ok {
+		return nil, false
+	}
+	enc, err := chain.Serialize(m)
+	return enc, err == nil
+}
+
+func diffLine(path string, exp, act reflect.Value) string {
+	return fmt.Sprintf("%s: expected %s, actual %s", pathName(path), formatValue(exp), formatValue(act))
+}
+
+func pathName(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return strings.TrimPrefix(path, ".")
+}
+
+func formatValue(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return "nil"
+	}
+	if b, ok := v.Interface().([]byte); ok {
+		return fmt.Sprintf("%x", b)
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
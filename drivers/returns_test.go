@@ -0,0 +1,116 @@
+package drivers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain"
+)
+
+type diffInner struct {
+	Count uint64
+	Data  []byte
+}
+
+type diffOuter struct {
+	Addr   address.Address
+	Amount big.Int
+	Inner  *diffInner
+	Items  []diffInner
+	Bits   bitfield.BitField
+	hidden int
+}
+
+func TestDiffValues(t *testing.T) {
+	addr := func(id uint64) address.Address {
+		a, err := address.NewIDAddress(id)
+		require.NoError(t, err)
+		return a
+	}
+	// decodedBits holds `bits` as decoded from their encoding, rather than as a set.
+	decodedBits := func(bits ...uint64) bitfield.BitField {
+		var bf bitfield.BitField
+		require.NoError(t, chain.Deserialize(chain.MustSerialize(bitfield.NewFromSet(bits)), &bf))
+		return bf
+	}
+	base := func() *diffOuter {
+		return &diffOuter{
+			Addr:   addr(100),
+			Amount: big.NewInt(1),
+			Inner:  &diffInner{Count: 1, Data: []byte{1, 2}},
+			Items:  []diffInner{{Count: 1}, {Count: 1}},
+			Bits:   bitfield.NewFromSet([]uint64{1, 2}),
+		}
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		mutate func(v *diffOuter)
+		// paths are those of the fields reported to differ.
+		paths []string
+	}{
+		{desc: "equal", mutate: func(v *diffOuter) {}},
+		{desc: "address", mutate: func(v *diffOuter) { v.Addr = addr(101) }, paths: []string{"Addr"}},
+		{desc: "stringer compared whole", mutate: func(v *diffOuter) { v.Amount = big.NewInt(2) }, paths: []string{"Amount"}},
+		{desc: "through pointer", mutate: func(v *diffOuter) { v.Inner.Count = 2 }, paths: []string{"Inner.Count"}},
+		{desc: "nil pointer", mutate: func(v *diffOuter) { v.Inner = nil }, paths: []string{"Inner"}},
+		{desc: "bytes compared whole", mutate: func(v *diffOuter) { v.Inner.Data = []byte{1, 3} }, paths: []string{"Inner.Data"}},
+		{desc: "slice element", mutate: func(v *diffOuter) { v.Items[1].Count = 2 }, paths: []string{"Items[1].Count"}},
+		{desc: "slice length", mutate: func(v *diffOuter) { v.Items = v.Items[:1] }, paths: []string{"Items"}},
+		{
+			desc:   "slice length and element",
+			mutate: func(v *diffOuter) { v.Items = []diffInner{{Count: 2}} },
+			paths:  []string{"Items", "Items[0].Count"},
+		},
+		{desc: "same encoding", mutate: func(v *diffOuter) { v.Bits = decodedBits(1, 2) }},
+		{desc: "other bits", mutate: func(v *diffOuter) { v.Bits = decodedBits(1, 3) }, paths: []string{"Bits"}},
+		{desc: "unexported field ignored", mutate: func(v *diffOuter) { v.hidden = 1 }},
+		{
+			desc:   "several fields",
+			mutate: func(v *diffOuter) { v.Addr, v.Inner.Count = addr(101), 2 },
+			paths:  []string{"Addr", "Inner.Count"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			act := base()
+			tc.mutate(act)
+
+			var paths []string
+			for _, line := range diffValues("", reflect.ValueOf(base()), reflect.ValueOf(act)) {
+				paths = append(paths, line[:strings.Index(line, ":")])
+			}
+			assert.Equal(t, tc.paths, paths)
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		exp, act interface{}
+		lines    []string
+	}{
+		{desc: "whole value", exp: uint64(1), act: uint64(2), lines: []string{"value: expected 1, actual 2"}},
+		{desc: "bytes in hex", exp: &diffInner{Data: []byte{0xab}}, act: &diffInner{Data: []byte{0xcd}},
+			lines: []string{"Data: expected ab, actual cd"}},
+		{desc: "nil pointer", exp: (*diffInner)(nil), act: &diffInner{Count: 1},
+			lines: []string{"value: expected nil, actual &{1 []}"}},
+		{desc: "element count", exp: []uint64{1, 2}, act: []uint64{1},
+			lines: []string{"value: expected 2 elements, actual 1"}},
+		{desc: "element", exp: []uint64{1, 2}, act: []uint64{1, 3},
+			lines: []string{"[1]: expected 2, actual 3"}},
+		// a nil slice decodes as an empty one.
+		{desc: "nil and empty slices", exp: []uint64(nil), act: []uint64{}},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.lines, diffValues("", reflect.ValueOf(tc.exp), reflect.ValueOf(tc.act)))
+		})
+	}
+}
@@ -2,13 +2,13 @@ package drivers
 
 import (
 	"context"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/filecoin-project/go-address"
 	abi_spec "github.com/filecoin-project/go-state-types/abi"
 	big_spec "github.com/filecoin-project/go-state-types/big"
-	miner_spec "github.com/filecoin-project/specs-actors/actors/builtin/miner"
-	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
 	acrypto "github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/specs-actors/actors/runtime"
 	adt_spec "github.com/filecoin-project/specs-actors/actors/util/adt"
@@ -17,8 +17,8 @@ import (
 	cbg "github.com/whyrusleeping/cbor-gen"
 
 	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
-	account_spec "github.com/filecoin-project/specs-actors/actors/builtin/account"
 
+	"github.com/filecoin-project/chain-validation/chain/actors"
 	"github.com/filecoin-project/chain-validation/state"
 	"github.com/filecoin-project/chain-validation/suites/utils"
 )
@@ -45,6 +45,7 @@ type StateDriver struct {
 	st state.VMWrapper
 	w  state.KeyManager
 	rs state.RandomnessSource
+	av actors.Actors
 
 	minerInfo *MinerInfo
 
@@ -61,9 +62,9 @@ type MinerInfo struct {
 	WorkerID address.Address
 }
 
-// NewStateDriver creates a new state driver for a state.
-func NewStateDriver(tb testing.TB, st state.VMWrapper, w state.KeyManager) *StateDriver {
-	return &StateDriver{tb, st, w, NewRandomnessSource(), nil, make(map[address.Address]address.Address)}
+// NewStateDriver creates a new state driver for a state holding version `av` of the actors.
+func NewStateDriver(tb testing.TB, st state.VMWrapper, w state.KeyManager, av actors.Actors) *StateDriver {
+	return &StateDriver{tb, st, w, NewRandomnessSource(), av, nil, make(map[address.Address]address.Address)}
 }
 
 // State returns the state.
@@ -79,6 +80,31 @@ func (d *StateDriver) Randomness() state.RandomnessSource {
 	return d.rs
 }
 
+// Actors returns the version of the actors the state holds.
+func (d *StateDriver) Actors() actors.Actors {
+	return d.av
+}
+
+// ExportState writes the current state tree to `w` as a CAR file, returning its root.
+func (d *StateDriver) ExportState(w io.Writer) cid.Cid {
+	root := d.st.Root()
+	require.NoError(d.tb, state.ExportCAR(d.st, root, w))
+	return root
+}
+
+// ImportState replaces the state tree with the one in the CAR file read from `r`, returning its root. The test is
+// skipped when the state wrapper cannot adopt an imported root.
+func (d *StateDriver) ImportState(r io.Reader) cid.Cid {
+	root, err := state.ImportCAR(d.st, r)
+	require.NoError(d.tb, err)
+	if err := state.SetRoot(d.st, root); errors.Is(err, state.ErrUnsupported) {
+		d.tb.Skipf("skipping test: %s", err)
+	} else {
+		require.NoError(d.tb, err)
+	}
+	return root
+}
+
 func (d *StateDriver) GetState(c cid.Cid, out cbg.CBORUnmarshaler) {
 	err := d.st.StoreGet(c, out)
 	require.NoError(d.tb, err)
@@ -110,7 +136,7 @@ func (d *StateDriver) NewAccountActor(addrType address.Protocol, balanceAttoFil
 		require.FailNowf(d.tb, "unsupported address", "protocol for account actor: %v", addrType)
 	}
 
-	_, idAddr, err := d.st.CreateActor(builtin_spec.AccountActorCodeID, addr, balanceAttoFil, &account_spec.State{Address: addr})
+	_, idAddr, err := d.st.CreateActor(d.av.Codes().Account, addr, balanceAttoFil, d.av.NewAccountState(addr))
 	require.NoError(d.tb, err)
 	d.actorIDMap[idAddr] = addr
 	return addr, idAddr
@@ -146,57 +172,32 @@ func (d *StateDriver) newMinerAccountActor(sealProofType abi_spec.RegisteredSeal
 		WorkerID: minerWorkerID,
 	}
 
-	ss, err := sealProofType.SectorSize()
-	require.NoError(d.tb, err)
-	ps, err := builtin_spec.SealProofWindowPoStPartitionSectors(sealProofType)
-	require.NoError(d.tb, err)
-	mi := &miner_spec.MinerInfo{
-		Owner:                      minerOwnerID,
-		Worker:                     minerWorkerID,
-		PendingWorkerKey:           nil,
-		PeerId:                     abi_spec.PeerID("chain-validation"),
-		Multiaddrs:                 nil,
-		SealProofType:              sealProofType,
-		SectorSize:                 ss,
-		WindowPoStPartitionSectors: ps,
-	}
-	mc, err := d.st.StorePut(mi)
+	minerState, minerPower, err := d.av.NewMinerState(AsStore(d.st), actors.MinerParams{
+		Owner:              minerOwnerID,
+		Worker:             minerWorkerID,
+		Peer:               abi_spec.PeerID("chain-validation"),
+		SealProofType:      sealProofType,
+		ProvingPeriodStart: periodBoundary,
+	})
 	require.NoError(d.tb, err)
 
 	// create the miner actor s.t. it exists in the init actors map
-	minerState, err := miner_spec.ConstructState(mc, periodBoundary, EmptyBitfieldCid, EmptyArrayCid, EmptyMapCid, EmptyDeadlinesCid, EmptyVestingFundsCid)
-	require.NoError(d.tb, err)
-
-	_, minerActorIDAddr, err := d.State().CreateActor(builtin_spec.StorageMinerActorCodeID, minerActorAddrs.RobustAddress, big_spec.Zero(), minerState)
+	_, minerActorIDAddr, err := d.State().CreateActor(d.av.Codes().Miner, minerActorAddrs.RobustAddress, big_spec.Zero(), minerState)
 	require.NoError(d.tb, err)
 	require.Equal(d.tb, expectedMinerActorIDAddress, minerActorIDAddr)
 
 	// a miner actor has been created, exists in the state tree, and has an entry in the init actor.
 	// next update the storage power actor to track the miner
 
-	var spa power_spec.State
-	d.GetActorState(builtin_spec.StoragePowerActorAddr, &spa)
-
-	// set the miners claim
-	hm, err := adt_spec.AsMap(AsStore(d.State()), spa.Claims)
+	powerActor, err := d.State().Actor(builtin_spec.StoragePowerActorAddr)
 	require.NoError(d.tb, err)
 
-	// add claim for the miner
-	err = hm.Put(adt_spec.AddrKey(minerActorIDAddr), &power_spec.Claim{
-		RawBytePower:    abi_spec.NewStoragePower(0),
-		QualityAdjPower: abi_spec.NewTokenAmount(0),
-	})
-	require.NoError(d.tb, err)
-
-	// save the claim
-	spa.Claims, err = hm.Root()
+	// add a claim for the miner and update the miner count
+	spa, err := d.av.AddMinerToPower(AsStore(d.State()), powerActor.Head(), minerActorIDAddr, minerPower)
 	require.NoError(d.tb, err)
 
-	// update miner count
-	spa.MinerCount += 1
-
 	// update storage power actor's state in the tree
-	d.PutState(&spa)
+	d.PutState(spa)
 
 	return minerActorIDAddr
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -12,17 +13,11 @@ import (
 	"github.com/filecoin-project/go-address"
 	abi_spec "github.com/filecoin-project/go-state-types/abi"
 	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
 	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
-	account_spec "github.com/filecoin-project/specs-actors/actors/builtin/account"
-	cron_spec "github.com/filecoin-project/specs-actors/actors/builtin/cron"
 	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
-	market_spec "github.com/filecoin-project/specs-actors/actors/builtin/market"
 	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
 	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
-	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
-	reward_spec "github.com/filecoin-project/specs-actors/actors/builtin/reward"
-	"github.com/filecoin-project/specs-actors/actors/builtin/system"
-	runtime_spec "github.com/filecoin-project/specs-actors/actors/runtime"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	adt_spec "github.com/filecoin-project/specs-actors/actors/util/adt"
 	cid "github.com/ipfs/go-cid"
@@ -31,8 +26,10 @@ import (
 	cbor "github.com/ipfs/go-ipld-cbor"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
 	"github.com/filecoin-project/chain-validation/chain/types"
 	"github.com/filecoin-project/chain-validation/state"
 	"github.com/filecoin-project/chain-validation/tracker"
@@ -49,6 +46,8 @@ var (
 	EmptyBitfieldCid     cid.Cid
 )
 
+// The builtin actors of actors.V0 every test starts from. TestDriverBuilder.WithDefaultBuiltinActorsState installs
+// those of the actors version the test runs against instead.
 var (
 	DefaultInitActorState          ActorState
 	DefaultRewardActorState        ActorState
@@ -70,81 +69,22 @@ func init() {
 		panic(err)
 	}
 
-	DefaultInitActorState = ActorState{
-		Addr:    builtin_spec.InitActorAddr,
-		Balance: big_spec.Zero(),
-		Code:    builtin_spec.InitActorCodeID,
-		State:   init_spec.ConstructState(EmptyMapCid, "chain-validation"),
-	}
-
-	firstRewardState := reward_spec.ConstructState(big_spec.Zero())
-	firstRewardState.ThisEpochReward = big_spec.NewInt(1e17)
-
-	DefaultRewardActorState = ActorState{
-		Addr:    builtin_spec.RewardActorAddr,
-		Balance: TotalNetworkBalance,
-		Code:    builtin_spec.RewardActorCodeID,
-		State:   firstRewardState,
-	}
-
-	DefaultBurntFundsActorState = ActorState{
-		Addr:    builtin_spec.BurntFundsActorAddr,
-		Balance: big_spec.Zero(),
-		Code:    builtin_spec.AccountActorCodeID,
-		State:   &account_spec.State{Address: builtin_spec.BurntFundsActorAddr},
-	}
-
-	DefaultStoragePowerActorState = ActorState{
-		Addr:    builtin_spec.StoragePowerActorAddr,
-		Balance: big_spec.Zero(),
-		Code:    builtin_spec.StoragePowerActorCodeID,
-		State:   power_spec.ConstructState(EmptyMapCid, EmptyMultiMapCid),
-	}
-
-	DefaultStorageMarketActorState = ActorState{
-		Addr:    builtin_spec.StorageMarketActorAddr,
-		Balance: big_spec.Zero(),
-		Code:    builtin_spec.StorageMarketActorCodeID,
-		State: &market_spec.State{
-			Proposals:        EmptyArrayCid,
-			States:           EmptyArrayCid,
-			PendingProposals: EmptyMapCid,
-			EscrowTable:      EmptyMapCid,
-			LockedTable:      EmptyMapCid,
-			NextID:           abi_spec.DealID(0),
-			DealOpsByEpoch:   EmptyMultiMapCid,
-			LastCron:         0,
-		},
-	}
-
-	DefaultSystemActorState = ActorState{
-		Addr:    builtin_spec.SystemActorAddr,
-		Balance: big_spec.Zero(),
-		Code:    builtin_spec.SystemActorCodeID,
-		State:   &system.State{},
-	}
-
-	DefaultCronActorState = ActorState{
-		Addr:    builtin_spec.CronActorAddr,
-		Balance: big_spec.Zero(),
-		Code:    builtin_spec.CronActorCodeID,
-		State: &cron_spec.State{Entries: []cron_spec.Entry{
-			{
-				Receiver:  builtin_spec.StoragePowerActorAddr,
-				MethodNum: builtin_spec.MethodsPower.OnEpochTickEnd,
-			},
-		}},
+	var err error
+	DefaultBuiltinActorsState, err = actors.V0.BuiltinActorsState(ms)
+	if err != nil {
+		panic(err)
 	}
-
-	DefaultBuiltinActorsState = []ActorState{
-		DefaultInitActorState,
-		DefaultRewardActorState,
-		DefaultBurntFundsActorState,
-		DefaultStoragePowerActorState,
-		DefaultStorageMarketActorState,
-		DefaultSystemActorState,
-		DefaultCronActorState,
+	byAddr := make(map[address.Address]ActorState)
+	for _, act := range DefaultBuiltinActorsState {
+		byAddr[act.Addr] = act
 	}
+	DefaultInitActorState = byAddr[builtin_spec.InitActorAddr]
+	DefaultRewardActorState = byAddr[builtin_spec.RewardActorAddr]
+	DefaultBurntFundsActorState = byAddr[builtin_spec.BurntFundsActorAddr]
+	DefaultStoragePowerActorState = byAddr[builtin_spec.StoragePowerActorAddr]
+	DefaultStorageMarketActorState = byAddr[builtin_spec.StorageMarketActorAddr]
+	DefaultSystemActorState = byAddr[builtin_spec.SystemActorAddr]
+	DefaultCronActorState = byAddr[builtin_spec.CronActorAddr]
 }
 
 func initializeStoreWithAdtRoots(store adt_spec.Store) error {
@@ -209,20 +149,62 @@ type TestDriverBuilder struct {
 	defaultGasFeeCap  abi_spec.TokenAmount
 	defaultGasPremium abi_spec.TokenAmount
 	defaultGasLimit   int64
+
+	networkVersion network.Version
+	upgrades       state.UpgradeSchedule
+
+	actorsVersion        actors.Version
+	defaultBuiltinActors bool
+	// verifiedRegistryRootKey administers the verified registry actor, which is only installed when it is set.
+	verifiedRegistryRootKey address.Address
+
+	genesis *GenesisSpec
+
+	keyManager state.KeyManager
 }
 
 func NewBuilder(ctx context.Context, factory state.Factories) *TestDriverBuilder {
 	return &TestDriverBuilder{
-		factory: factory,
-		ctx:     ctx,
+		factory:        factory,
+		ctx:            ctx,
+		networkVersion: types.DefaultNetworkVersion,
+		actorsVersion:  actors.DefaultVersion,
+	}
+}
+
+type ActorState = actors.ActorState
+
+// Copy returns a builder configured like `b`, whose configuration can be changed without affecting `b`, e.g. to build
+// a driver for each of several network versions from a shared builder.
+func (b *TestDriverBuilder) Copy() *TestDriverBuilder {
+	c := *b
+	c.actorStates = append([]ActorState(nil), b.actorStates...)
+	c.upgrades = append(state.UpgradeSchedule(nil), b.upgrades...)
+	if b.genesis != nil {
+		spec := *b.genesis
+		c.genesis = &spec
 	}
+	return &c
 }
 
-type ActorState struct {
-	Addr    address.Address
-	Balance abi_spec.TokenAmount
-	Code    cid.Cid
-	State   runtime_spec.CBORMarshaler
+// WithDefaultBuiltinActorsState installs the builtin actors of the actors version the test runs against, before any
+// actors added with WithActorState.
+func (b *TestDriverBuilder) WithDefaultBuiltinActorsState() *TestDriverBuilder {
+	b.defaultBuiltinActors = true
+	return b
+}
+
+// WithVerifiedRegistry installs the verified registry actor, administered by `rootKey`, after the builtin actors.
+// It is not one of the default builtin actors, so that the state roots recorded for tests without it are unchanged.
+func (b *TestDriverBuilder) WithVerifiedRegistry(rootKey address.Address) *TestDriverBuilder {
+	b.verifiedRegistryRootKey = rootKey
+	return b
+}
+
+// WithActorsVersion sets the version of the actors the driver constructs states, messages and assertions for.
+func (b *TestDriverBuilder) WithActorsVersion(v actors.Version) *TestDriverBuilder {
+	b.actorsVersion = v
+	return b
 }
 
 func (b *TestDriverBuilder) WithActorState(acts ...ActorState) *TestDriverBuilder {
@@ -245,25 +227,111 @@ func (b *TestDriverBuilder) WithDefaultGasPremium(premium int64) *TestDriverBuil
 	return b
 }
 
+// WithNetworkVersion sets the network version messages are executed under. Tests are skipped when the implementation
+// does not support the version.
+func (b *TestDriverBuilder) WithNetworkVersion(nv network.Version) *TestDriverBuilder {
+	b.networkVersion = nv
+	return b
+}
+
+// WithUpgradeSchedule sets the network upgrades of the chain, the network and actors versions set with
+// WithNetworkVersion and WithActorsVersion being those of genesis. Tests are skipped when the implementation does not
+// support the upgrades. The driver follows the state to the actors version of each upgrade it crosses.
+func (b *TestDriverBuilder) WithUpgradeSchedule(upgrades ...state.Upgrade) *TestDriverBuilder {
+	b.upgrades = upgrades
+	return b
+}
+
+// WithGenesis installs the actors of `spec` after the builtin actors and those added with WithActorState. When the
+// spec declares miners the first one mines the driver's blocks and the builtin miner is not installed.
+func (b *TestDriverBuilder) WithGenesis(spec GenesisSpec) *TestDriverBuilder {
+	b.genesis = &spec
+	return b
+}
+
+// WithKeyManager sets the key manager creating and signing for the accounts of the driver, instead of the one made by
+// the factory.
+func (b *TestDriverBuilder) WithKeyManager(km state.KeyManager) *TestDriverBuilder {
+	b.keyManager = km
+	return b
+}
+
 func (b *TestDriverBuilder) Build(t testing.TB) *TestDriver {
 	syscalls := NewChainValidationSysCalls()
 	stateWrapper, applier := b.factory.NewStateAndApplier(syscalls)
-	sd := NewStateDriver(t, stateWrapper, b.factory.NewKeyManager())
+	validator := chain.NewValidator(applier)
+	if err := validator.SetNetworkVersion(b.networkVersion); errors.Is(err, state.ErrUnsupportedNetworkVersion) {
+		t.Skipf("skipping test: %s", err)
+	} else {
+		require.NoError(t, err)
+	}
+	if len(b.upgrades) > 0 {
+		if err := validator.SetUpgradeSchedule(b.networkVersion, b.upgrades); errors.Is(err, state.ErrUnsupportedNetworkVersion) {
+			t.Skipf("skipping test: %s", err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	for _, u := range b.upgrades {
+		_, err := actors.Get(u.Actors)
+		require.NoError(t, err, "upgrade at height %d", u.Height)
+	}
+	av, err := actors.Get(b.actorsVersion)
+	require.NoError(t, err)
+	km := b.keyManager
+	if km == nil {
+		km = b.factory.NewKeyManager()
+	}
+	sd := NewStateDriver(t, stateWrapper, km, av)
 	stateWrapper.NewVM()
 
-	err := initializeStoreWithAdtRoots(AsStore(sd.st))
+	err = av.InitializeStore(AsStore(sd.st))
 	require.NoError(t, err)
 
-	for _, acts := range b.actorStates {
+	actorStates := b.actorStates
+	if b.defaultBuiltinActors {
+		builtins, err := av.BuiltinActorsState(AsStore(sd.st))
+		require.NoError(t, err)
+		actorStates = append(builtins, actorStates...)
+	}
+	if b.verifiedRegistryRootKey != address.Undef {
+		verifreg, err := av.NewVerifiedRegistryState(AsStore(sd.st), b.verifiedRegistryRootKey)
+		require.NoError(t, err)
+		actorStates = append(actorStates, ActorState{
+			Addr:    builtin_spec.VerifiedRegistryActorAddr,
+			Balance: big_spec.Zero(),
+			Code:    av.Codes().VerifiedReg,
+			State:   verifreg,
+		})
+	}
+	for _, acts := range actorStates {
 		_, _, err := sd.State().CreateActor(acts.Code, acts.Addr, acts.Balance, acts.State)
 		require.NoError(t, err)
 	}
 
-	minerActorIDAddr := sd.newMinerAccountActor(TestSealProofType, abi_spec.ChainEpoch(0))
+	var genesis *Genesis
+	if b.genesis != nil {
+		genesis = sd.installGenesis(*b.genesis)
+	}
+
+	var minerActorIDAddr address.Address
+	if b.genesis != nil && len(b.genesis.Miners) > 0 {
+		m := b.genesis.Miners[0]
+		minerActorIDAddr = genesis.ID(m.Name)
+		sd.minerInfo = &MinerInfo{
+			Owner:    genesis.Robust(m.Owner),
+			OwnerID:  genesis.ID(m.Owner),
+			Worker:   genesis.Robust(m.Worker),
+			WorkerID: genesis.ID(m.Worker),
+		}
+	} else {
+		minerActorIDAddr = sd.newMinerAccountActor(TestSealProofType, abi_spec.ChainEpoch(0))
+	}
 
 	exeCtx := types.NewExecutionContext(1, minerActorIDAddr)
-	producer := chain.NewMessageProducer(b.defaultGasFeeCap, b.defaultGasPremium, b.defaultGasLimit)
-	validator := chain.NewValidator(applier)
+	exeCtx.NetworkVersion = b.networkVersion
+	producer := chain.NewMessageProducer(av, b.defaultGasFeeCap, b.defaultGasPremium, b.defaultGasLimit)
+	config := b.factory.NewValidationConfig()
 
 	return &TestDriver{
 		T:               t,
@@ -271,12 +339,16 @@ func (b *TestDriverBuilder) Build(t testing.TB) *TestDriver {
 		MessageProducer: producer,
 		validator:       validator,
 		ExeCtx:          exeCtx,
+		Genesis:         genesis,
 
-		Config: b.factory.NewValidationConfig(),
+		Config: config,
 
-		StateTracker: tracker.NewStateTracker(t),
+		StateTracker: tracker.NewStateTracker(t, state.ExpectationProfiles(config)),
 
 		SysCalls: syscalls,
+
+		upgrades:      b.upgrades,
+		genesisActors: b.actorsVersion,
 	}
 }
 
@@ -288,12 +360,25 @@ type TestDriver struct {
 	TipSetMessageBuilder *TipSetMessageBuilder
 	validator            *chain.Validator
 	ExeCtx               *types.ExecutionContext
+	// Genesis holds the actors installed with TestDriverBuilder.WithGenesis, nil without a genesis spec.
+	Genesis *Genesis
 
 	Config state.ValidationConfig
 
 	StateTracker *tracker.StateTracker
 
 	SysCalls *ChainValidationSysCalls
+
+	// Panics holds the panics of the implementation reported so far, see state.PanicTolerator.
+	Panics []*ImplementationPanic
+	// lastPanic is the panic of the last message or tipset applied, nil if it did not panic.
+	lastPanic *ImplementationPanic
+	// recording holds the messages and tipsets applied since StartRecording, nil if it was not called.
+	recording *Recording
+
+	// upgrades of the chain, and the actors version of its genesis state.
+	upgrades      state.UpgradeSchedule
+	genesisActors actors.Version
 }
 
 func (td *TestDriver) Complete() {
@@ -326,23 +411,29 @@ func (td *TestDriver) ApplyFailure(msg *types.Message, code exitcode.ExitCode) t
 	return td.applyMessageExpectCodeAndReturn(msg, code, EmptyReturnValue)
 }
 
+// ApplyExpectVersioned applies a message, expecting the result for the network version of the driver.
+func (td *TestDriver) ApplyExpectVersioned(msg *types.Message, expected *VersionedResult) types.ApplyMessageResult {
+	res := expected.At(td.ExeCtx.NetworkVersion)
+	return td.applyMessageExpectCodeAndReturn(msg, res.ExitCode, res.ReturnVal)
+}
+
 func (td *TestDriver) applyMessageExpectCodeAndReturn(msg *types.Message, code exitcode.ExitCode, retval []byte) types.ApplyMessageResult {
 	result := td.applyMessage(msg)
-	td.validateResult(result, code, retval)
+	td.validateResult(result, ExpectedResult{ExitCode: code, ReturnVal: retval})
 	td.validateState(msg, result)
 	return result
 }
 
 func (td *TestDriver) applyMessage(msg *types.Message) (result types.ApplyMessageResult) {
-	defer func() {
-		if r := recover(); r != nil {
-			td.T.Fatalf("message application panicked: %v", r)
-		}
-	}()
-
-	result, err := td.validator.ApplyMessage(td.ExeCtx.Epoch, msg)
-	require.NoError(td.T, err)
+	td.record(ReplayStep{Epoch: td.ExeCtx.Epoch, Miner: td.ExeCtx.Miner, Message: msg})
+	pre := td.capturePreState()
+	var err error
+	if td.applyGuarded("message", func() { result, err = td.validator.ApplyMessage(td.ExeCtx, msg) }) == nil {
+		require.NoError(td.T, err)
+		td.checkInvariants(pre, "message")
+	}
 
+	// the empty result of a tolerated panic is tracked too, keeping the tracked results in step with those expected.
 	td.StateTracker.TrackResult(result)
 	return result
 }
@@ -371,67 +462,147 @@ func (td *TestDriver) ApplySignedFailure(msg *types.Message, code exitcode.ExitC
 
 func (td *TestDriver) applyMessageSignedExpectCodeAndReturn(msg *types.Message, code exitcode.ExitCode, retval []byte) types.ApplyMessageResult {
 	result := td.applyMessageSigned(msg)
-	td.validateResult(result, code, retval)
+	td.validateResult(result, ExpectedResult{ExitCode: code, ReturnVal: retval})
 	td.validateState(msg, result)
 	return result
 }
-func (td *TestDriver) applyMessageSigned(msg *types.Message) (result types.ApplyMessageResult) {
-	defer func() {
-		if r := recover(); r != nil {
-			td.T.Fatalf("message application panicked: %v", r)
-		}
-	}()
+func (td *TestDriver) applyMessageSigned(msg *types.Message) types.ApplyMessageResult {
 	serMsg, err := msg.Serialize()
 	require.NoError(td.T, err)
 
 	msgSig, err := td.Wallet().Sign(msg.From, serMsg)
 	require.NoError(td.T, err)
 
-	smsgs := &types.SignedMessage{
+	return td.applySignedMessage(&types.SignedMessage{
 		Message:   *msg,
 		Signature: msgSig,
+	})
+}
+
+func (td *TestDriver) applySignedMessage(smsg *types.SignedMessage) (result types.ApplyMessageResult) {
+	td.record(ReplayStep{Epoch: td.ExeCtx.Epoch, Miner: td.ExeCtx.Miner, SignedMessage: smsg})
+	pre := td.capturePreState()
+	var err error
+	if td.applyGuarded("signed message", func() { result, err = td.validator.ApplySignedMessage(td.ExeCtx, smsg) }) == nil {
+		require.NoError(td.T, err)
+		td.checkInvariants(pre, "signed message")
 	}
-	result, err = td.validator.ApplySignedMessage(td.ExeCtx.Epoch, smsgs)
-	require.NoError(td.T, err)
 
+	// the empty result of a tolerated panic is tracked too, keeping the tracked results in step with those expected.
 	td.StateTracker.TrackResult(result)
 	return result
 }
 
-func (td *TestDriver) validateResult(result types.ApplyMessageResult, code exitcode.ExitCode, retval []byte) {
+func (td *TestDriver) validateResult(result types.ApplyMessageResult, expected ExpectedResult) {
+	if td.lastPanic != nil {
+		return
+	}
 	if td.Config.ValidateExitCode() {
-		assert.Equal(td.T, code, result.Receipt.ExitCode, "Expected ExitCode: %s Actual ExitCode: %s", code.Error(), result.Receipt.ExitCode.Error())
+		assert.Equal(td.T, expected.ExitCode, result.Receipt.ExitCode, "Expected ExitCode: %s Actual ExitCode: %s", expected.ExitCode.Error(), result.Receipt.ExitCode.Error())
 	}
 	if td.Config.ValidateReturnValue() {
-		assert.Equal(td.T, retval, result.Receipt.ReturnValue, "Expected ReturnValue: %v Actual ReturnValue: %v", retval, result.Receipt.ReturnValue)
+		td.validateReturnValue("", expected, result.Receipt.ReturnValue)
 	}
 }
 
 func (td *TestDriver) validateState(msg *types.Message, result types.ApplyMessageResult) {
-	if td.Config.ValidateGas() {
-		expectedGasUsed, ok := td.StateTracker.NextExpectedGas()
-		if ok {
-			assert.Equal(td.T, expectedGasUsed, result.Receipt.GasUsed, "Expected GasUsed: %d Actual GasUsed: %d", expectedGasUsed, result.Receipt.GasUsed)
-		} else {
-			td.T.Logf("WARNING (not a test failure): failed to find expected gas cost for message: %+v", msg)
-		}
+	// the expected result is consumed even after a panic, so the next message is validated against its own.
+	expected, found := td.StateTracker.NextExpectedMessageResult()
+	if !found {
+		td.T.Logf("WARNING (not a test failure): failed to find expected result for message: %+v", msg)
+		return
+	}
+	if td.lastPanic != nil {
+		return
+	}
+
+	td.validateRecordedReceipt("", expected.Receipt, result.Receipt)
+	if td.Config.ValidateRewardsAndPenalties() {
+		td.assertTokenAmount("Penalty", expected.Penalty, result.Penalty)
+		td.assertTokenAmount("Reward", expected.Reward, result.Reward)
 	}
 	if td.Config.ValidateStateRoot() {
-		expectedRoot, found := td.StateTracker.NextExpectedStateRoot()
+		expectedRoot := expected.StateRoot()
 		actualRoot := td.State().Root()
-		if found {
-			assert.Equal(td.T, expectedRoot, actualRoot, "Expected StateRoot: %s Actual StateRoot: %s", expectedRoot, actualRoot)
-		} else {
-			td.T.Log("WARNING: failed to find expected state  root for message number")
-		}
+		assert.Equal(td.T, expectedRoot, actualRoot, "Expected StateRoot: %s Actual StateRoot: %s", expectedRoot, actualRoot)
+	}
+}
+
+// validateRecordedReceipt compares the parts of a receipt enabled by the validation config with the receipt recorded
+// for the same message. `desc` prefixes failure messages to identify the message.
+func (td *TestDriver) validateRecordedReceipt(desc string, expected, actual types.MessageReceipt) {
+	if td.Config.ValidateGas() {
+		assert.Equal(td.T, expected.GasUsed, actual.GasUsed, "%sExpected GasUsed: %d Actual GasUsed: %d", desc, expected.GasUsed, actual.GasUsed)
+	}
+	if td.Config.ValidateExitCode() {
+		assert.Equal(td.T, expected.ExitCode, actual.ExitCode, "%sExpected ExitCode: %s Actual ExitCode: %s", desc, expected.ExitCode.Error(), actual.ExitCode.Error())
+	}
+	if td.Config.ValidateReturnValue() {
+		// recorded empty return values decode as an empty slice, which is equivalent to nil.
+		assert.True(td.T, bytes.Equal(expected.ReturnValue, actual.ReturnValue), "%sExpected ReturnValue: %x Actual ReturnValue: %x", desc, expected.ReturnValue, actual.ReturnValue)
 	}
 }
 
+func (td *TestDriver) assertTokenAmount(what string, expected, actual abi_spec.TokenAmount) {
+	if expected.Nil() || actual.Nil() {
+		assert.Equal(td.T, expected.Nil(), actual.Nil(), "Expected %s: %v Actual %s: %v", what, expected, what, actual)
+		return
+	}
+	assert.True(td.T, expected.Equals(actual), "Expected %s: %s Actual %s: %s", what, expected, what, actual)
+}
+
 func (td *TestDriver) AssertNoActor(addr address.Address) {
 	_, err := td.State().Actor(addr)
 	assert.Error(td.T, err, "expected no such actor %s", addr)
 }
 
+// SnapshotAllActors returns the current code, head, balance and call sequence number of every actor in the state
+// tree, keyed by ID address.
+func (td *TestDriver) SnapshotAllActors() map[address.Address]ActorSnapshot {
+	snapshot := make(map[address.Address]ActorSnapshot)
+	err := state.ForEachActor(td.State(), func(addr address.Address, actr state.Actor) error {
+		snapshot[addr] = snapshotActor(actr)
+		return nil
+	})
+	require.NoError(td.T, err)
+	return snapshot
+}
+
+// AssertActorCount asserts the state tree holds `expected` actors.
+func (td *TestDriver) AssertActorCount(expected int) {
+	actual := len(td.SnapshotAllActors())
+	assert.Equal(td.T, expected, actual, "Expected ActorCount: %d Actual ActorCount: %d", expected, actual)
+}
+
+// AssertOnlyActorsChanged asserts the actors in `before`, a snapshot taken with SnapshotAllActors, are unchanged
+// except those in `addrs`, and that no actor outside `addrs` was created or deleted since. `addrs` must be ID
+// addresses.
+func (td *TestDriver) AssertOnlyActorsChanged(before map[address.Address]ActorSnapshot, addrs ...address.Address) {
+	allowed := make(map[address.Address]bool, len(addrs))
+	for _, addr := range addrs {
+		allowed[addr] = true
+	}
+	after := td.SnapshotAllActors()
+	for addr, prev := range before {
+		if allowed[addr] {
+			continue
+		}
+		cur, ok := after[addr]
+		if !assert.True(td.T, ok, "actor %s was unexpectedly deleted", addr) {
+			continue
+		}
+		assert.Equal(td.T, prev.Code, cur.Code, "actor %s Expected Code: %s Actual Code: %s", addr, prev.Code, cur.Code)
+		assert.Equal(td.T, prev.Head, cur.Head, "actor %s Expected Head: %s Actual Head: %s", addr, prev.Head, cur.Head)
+		assert.Equal(td.T, prev.Balance.String(), cur.Balance.String(), "actor %s Expected Balance: %s Actual Balance: %s", addr, prev.Balance, cur.Balance)
+		assert.Equal(td.T, prev.CallSeqNum, cur.CallSeqNum, "actor %s Expected CallSeqNum: %d Actual CallSeqNum: %d", addr, prev.CallSeqNum, cur.CallSeqNum)
+	}
+	for addr := range after {
+		if _, ok := before[addr]; !ok && !allowed[addr] {
+			assert.Fail(td.T, "unexpected actor", "actor %s was unexpectedly created", addr)
+		}
+	}
+}
+
 func (td *TestDriver) GetBalance(addr address.Address) abi_spec.TokenAmount {
 	actr, err := td.State().Actor(addr)
 	require.NoError(td.T, err)
@@ -478,48 +649,46 @@ func (td *TestDriver) AssertBalanceCallback(addr address.Address, thing func(act
 }
 
 func (td *TestDriver) AssertMultisigTransaction(multisigAddr address.Address, txnID multisig_spec.TxnID, txn multisig_spec.Transaction) {
-	var msState multisig_spec.State
-	td.GetActorState(multisigAddr, &msState)
-
-	txnMap, err := adt_spec.AsMap(AsStore(td.State()), msState.PendingTxns)
-	require.NoError(td.T, err)
-
-	var actualTxn multisig_spec.Transaction
-	found, err := txnMap.Get(txnID, &actualTxn)
+	actualTxn, found, err := td.Actors().LoadMultisigTransaction(AsStore(td.State()), td.GetHead(multisigAddr), int64(txnID))
 	require.NoError(td.T, err)
 	require.True(td.T, found)
 
-	assert.Equal(td.T, txn, actualTxn)
+	assert.Equal(td.T, actors.MultisigTransaction{
+		To:       txn.To,
+		Value:    txn.Value,
+		Method:   txn.Method,
+		Params:   txn.Params,
+		Approved: txn.Approved,
+	}, *actualTxn)
 }
 
 func (td *TestDriver) AssertMultisigContainsTransaction(multisigAddr address.Address, txnID multisig_spec.TxnID, contains bool) {
-	var msState multisig_spec.State
-	td.GetActorState(multisigAddr, &msState)
-
-	txnMap, err := adt_spec.AsMap(AsStore(td.State()), msState.PendingTxns)
-	require.NoError(td.T, err)
-
-	var actualTxn multisig_spec.Transaction
-	found, err := txnMap.Get(txnID, &actualTxn)
+	_, found, err := td.Actors().LoadMultisigTransaction(AsStore(td.State()), td.GetHead(multisigAddr), int64(txnID))
 	require.NoError(td.T, err)
 
 	assert.Equal(td.T, contains, found)
 
 }
 
-func (td *TestDriver) AssertMultisigState(multisigAddr address.Address, expected multisig_spec.State) {
-	var msState multisig_spec.State
-	td.GetActorState(multisigAddr, &msState)
-	assert.NotNil(td.T, msState)
+// AssertMultisigState asserts the state of a multisig actor matches `expected`, its signers in any order. The pending
+// transactions are not compared.
+func (td *TestDriver) AssertMultisigState(multisigAddr address.Address, expected actors.MultisigState) {
+	msState, err := td.Actors().LoadMultisig(AsStore(td.State()), td.GetHead(multisigAddr))
+	require.NoError(td.T, err)
 	assert.Equal(td.T, expected.InitialBalance, msState.InitialBalance, fmt.Sprintf("expected InitialBalance: %v, actual InitialBalance: %v", expected.InitialBalance, msState.InitialBalance))
 	assert.Equal(td.T, expected.NextTxnID, msState.NextTxnID, fmt.Sprintf("expected NextTxnID: %v, actual NextTxnID: %v", expected.NextTxnID, msState.NextTxnID))
 	assert.Equal(td.T, expected.NumApprovalsThreshold, msState.NumApprovalsThreshold, fmt.Sprintf("expected NumApprovalsThreshold: %v, actual NumApprovalsThreshold: %v", expected.NumApprovalsThreshold, msState.NumApprovalsThreshold))
 	assert.Equal(td.T, expected.StartEpoch, msState.StartEpoch, fmt.Sprintf("expected StartEpoch: %v, actual StartEpoch: %v", expected.StartEpoch, msState.StartEpoch))
 	assert.Equal(td.T, expected.UnlockDuration, msState.UnlockDuration, fmt.Sprintf("expected UnlockDuration: %v, actual UnlockDuration: %v", expected.UnlockDuration, msState.UnlockDuration))
+	assert.ElementsMatch(td.T, expected.Signers, msState.Signers, fmt.Sprintf("expected Signers: %v, actual Signers: %v", expected.Signers, msState.Signers))
+}
 
-	for _, e := range expected.Signers {
-		assert.Contains(td.T, msState.Signers, e, fmt.Sprintf("expected Signer: %v, actual Signer: %v", e, msState.Signers))
-	}
+// MultisigLockedBalance returns the balance of the multisig actor at `multisigAddr` that cannot be spent at `epoch`,
+// computed from its vesting parameters.
+func (td *TestDriver) MultisigLockedBalance(multisigAddr address.Address, epoch abi_spec.ChainEpoch) abi_spec.TokenAmount {
+	msState, err := td.Actors().LoadMultisig(AsStore(td.State()), td.GetHead(multisigAddr))
+	require.NoError(td.T, err)
+	return td.Actors().MultisigLockedBalance(msState, epoch)
 }
 
 func (td *TestDriver) ComputeInitActorExecReturn(from address.Address, originatorCallSeq uint64, newActorAddressCount uint64, expectedNewAddr address.Address) init_spec.ExecReturn {
@@ -547,6 +716,31 @@ func computeInitActorExecReturn(t testing.TB, from address.Address, originatorCa
 	}
 }
 
+// AssertInitAddressMapping asserts the init actor maps the robust address `robust` to the ID address `id`.
+func (td *TestDriver) AssertInitAddressMapping(robust, id address.Address) {
+	var st init_spec.State
+	td.GetActorState(builtin_spec.InitActorAddr, &st)
+	addrMap, err := adt_spec.AsMap(AsStore(td.State()), st.AddressMap)
+	require.NoError(td.T, err)
+
+	var actorID cbg.CborInt
+	found, err := addrMap.Get(adt_spec.AddrKey(robust), &actorID)
+	require.NoError(td.T, err)
+	if !assert.True(td.T, found, "init actor does not map %s, expected %s", robust, id) {
+		return
+	}
+	actual, err := address.NewIDAddress(uint64(actorID))
+	require.NoError(td.T, err)
+	assert.Equal(td.T, id, actual, "init actor maps %s to %s, expected %s", robust, actual, id)
+}
+
+// InitNextID returns the ID the init actor assigns to the next address it maps.
+func (td *TestDriver) InitNextID() abi_spec.ActorID {
+	var st init_spec.State
+	td.GetActorState(builtin_spec.InitActorAddr, &st)
+	return st.NextID
+}
+
 func (td *TestDriver) MustCreateAndVerifyMultisigActor(nonce uint64, value abi_spec.TokenAmount, multisigAddr address.Address, from address.Address, params *multisig_spec.ConstructorParams, code exitcode.ExitCode, retval []byte) {
 	/* Create the Multisig actor*/
 	td.applyMessageExpectCodeAndReturn(
@@ -561,7 +755,7 @@ func (td *TestDriver) MustCreateAndVerifyMultisigActor(nonce uint64, value abi_s
 		initialBalance = value
 		startEpoch = td.ExeCtx.Epoch
 	}
-	td.AssertMultisigState(multisigAddr, multisig_spec.State{
+	td.AssertMultisigState(multisigAddr, actors.MultisigState{
 		NextTxnID:      0,
 		InitialBalance: initialBalance,
 		StartEpoch:     startEpoch,
@@ -584,12 +778,12 @@ type RewardSummary struct {
 }
 
 func (td *TestDriver) GetRewardSummary() *RewardSummary {
-	var rst reward_spec.State
-	td.GetActorState(builtin_spec.RewardActorAddr, &rst)
+	rst, err := td.Actors().LoadReward(AsStore(td.State()), td.GetHead(builtin_spec.RewardActorAddr))
+	require.NoError(td.T, err)
 
 	return &RewardSummary{
 		Treasury:           td.GetBalance(builtin_spec.RewardActorAddr),
 		NextPerEpochReward: rst.ThisEpochReward,
-		NextPerBlockReward: big_spec.Div(rst.ThisEpochReward, big_spec.NewInt(builtin_spec.ExpectedLeadersPerEpoch)),
+		NextPerBlockReward: big_spec.Div(rst.ThisEpochReward, big_spec.NewInt(rst.ExpectedLeadersPerEpoch)),
 	}
 }
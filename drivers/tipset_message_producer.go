@@ -1,10 +1,13 @@
 package drivers
 
 import (
+	"fmt"
+
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/chain-validation/chain/types"
 )
@@ -50,14 +53,29 @@ func (t *TipSetMessageBuilder) apply() types.ApplyTipSetResult {
 	for _, b := range t.bbs {
 		blks = append(blks, b.build())
 	}
-	result, err := t.driver.validator.ApplyTipSetMessages(t.driver.ExeCtx.Epoch, blks, t.driver.Randomness())
-	require.NoError(t.driver.T, err)
+	return t.driver.applyTipSet(blks)
+}
+
+func (td *TestDriver) applyTipSet(blks []types.BlockMessagesInfo) (result types.ApplyTipSetResult) {
+	td.record(ReplayStep{Epoch: td.ExeCtx.Epoch, Miner: td.ExeCtx.Miner, Blocks: blks})
+	what := fmt.Sprintf("tipset at epoch %d", td.ExeCtx.Epoch)
+	pre := td.capturePreState()
+	var err error
+	if td.applyGuarded(what, func() { result, err = td.validator.ApplyTipSetMessages(td.ExeCtx, blks, td.Randomness()) }) == nil {
+		require.NoError(td.T, err)
+		td.followUpgrades()
+		td.checkInvariants(pre, what)
+	}
 
-	t.driver.StateTracker.TrackResult(result)
+	// the empty result of a tolerated panic is tracked too, keeping the tracked results in step with those expected.
+	td.StateTracker.TrackResult(result)
 	return result
 }
 
 func (t *TipSetMessageBuilder) validateResult(result types.ApplyTipSetResult) {
+	if t.driver.lastPanic != nil {
+		return
+	}
 	expected := []ExpectedResult{}
 	for _, b := range t.bbs {
 		expected = append(expected, b.expectedResults...)
@@ -73,31 +91,47 @@ func (t *TipSetMessageBuilder) validateResult(result types.ApplyTipSetResult) {
 			assert.Equal(t.driver.T, expected[i].ExitCode, result.Receipts[i].ExitCode, "Message Number: %d Expected ExitCode: %s Actual ExitCode: %s", i, expected[i].ExitCode.Error(), result.Receipts[i].ExitCode.Error())
 		}
 		if t.driver.Config.ValidateReturnValue() {
-			assert.Equal(t.driver.T, expected[i].ReturnVal, result.Receipts[i].ReturnValue, "Message Number: %d Expected ReturnValue: %v Actual ReturnValue: %v", i, expected[i].ReturnVal, result.Receipts[i].ReturnValue)
+			t.driver.validateReturnValue(fmt.Sprintf("Message Number: %d ", i), expected[i], result.Receipts[i].ReturnValue)
 		}
 	}
 }
 
 func (t *TipSetMessageBuilder) validateState(result types.ApplyTipSetResult) {
-	if t.driver.Config.ValidateGas() {
-		for i := range result.Receipts {
-			expectedGas, found := t.driver.StateTracker.NextExpectedGas()
-			if found {
-				assert.Equal(t.driver.T, expectedGas, result.Receipts[i].GasUsed, "Message Number: %d Expected GasUsed: %d Actual GasUsed: %d", i, expectedGas, result.Receipts[i].GasUsed)
-			} else {
-				t.driver.T.Logf("WARNING: failed to find expected gas cost for message number: %d", i)
-			}
-		}
+	td := t.driver
+	// the expected result is consumed even after a panic, so the next tipset is validated against its own.
+	expected, found := td.StateTracker.NextExpectedTipSetResult()
+	if !found {
+		td.T.Log("WARNING (not a test failure): failed to find expected result for tipset")
+		return
+	}
+	if td.lastPanic != nil {
+		return
+	}
+
+	assert.Equal(td.T, len(expected.Receipts), len(result.Receipts), "Expected %d receipts Actual %d receipts", len(expected.Receipts), len(result.Receipts))
+	for i := 0; i < len(expected.Receipts) && i < len(result.Receipts); i++ {
+		td.validateRecordedReceipt(fmt.Sprintf("Message Number: %d ", i), expected.Receipts[i], result.Receipts[i])
 	}
-	if t.driver.Config.ValidateStateRoot() {
-		expectedRoot, found := t.driver.StateTracker.NextExpectedStateRoot()
-		actualRoot := t.driver.State().Root()
-		if found {
-			assert.Equal(t.driver.T, expectedRoot, actualRoot, "Expected StateRoot: %s Actual StateRoot: %s", expectedRoot, actualRoot)
+
+	if td.Config.ValidateRewardsAndPenalties() {
+		if len(expected.Rewards) == 0 {
+			td.T.Log("WARNING (not a test failure): no block rewards were recorded for tipset")
 		} else {
-			t.driver.T.Log("WARNING: failed to find expected state  root for message number")
+			assert.Equal(td.T, len(expected.Rewards), len(result.Rewards), "Expected rewards for %d blocks Actual rewards for %d blocks", len(expected.Rewards), len(result.Rewards))
+			for i := 0; i < len(expected.Rewards) && i < len(result.Rewards); i++ {
+				exp, act := expected.Rewards[i], result.Rewards[i]
+				assert.Equal(td.T, exp.Miner, act.Miner, "Block Number: %d Expected Miner: %s Actual Miner: %s", i, exp.Miner, act.Miner)
+				td.assertTokenAmount(fmt.Sprintf("Block Number: %d Penalty", i), exp.Penalty, act.Penalty)
+				td.assertTokenAmount(fmt.Sprintf("Block Number: %d Reward", i), exp.Reward, act.Reward)
+			}
 		}
 	}
+
+	if td.Config.ValidateStateRoot() {
+		expectedRoot := expected.StateRoot()
+		actualRoot := td.State().Root()
+		assert.Equal(td.T, expectedRoot, actualRoot, "Expected StateRoot: %s Actual StateRoot: %s", expectedRoot, actualRoot)
+	}
 }
 
 func (t *TipSetMessageBuilder) Clear() {
@@ -119,6 +153,8 @@ type BlockBuilder struct {
 type ExpectedResult struct {
 	ExitCode  exitcode.ExitCode
 	ReturnVal []byte
+	// Return is the decoded return value expected. When set it is compared instead of ReturnVal.
+	Return cbg.CBORUnmarshaler
 }
 
 func NewBlockBuilder(td *TestDriver, miner address.Address) *BlockBuilder {
@@ -189,6 +225,22 @@ func (bb *BlockBuilder) WithSECPMessageDropped(bm *types.Message) *BlockBuilder
 	return bb
 }
 
+// WithBLSMessageVersioned adds a BLS message, expecting the result for the network version of the driver.
+func (bb *BlockBuilder) WithBLSMessageVersioned(bm *types.Message, expected *VersionedResult) *BlockBuilder {
+	res := expected.At(bb.TD.ExeCtx.NetworkVersion)
+	bb.blsMsgs = append(bb.blsMsgs, bm)
+	bb.addResult(res.ExitCode, res.ReturnVal)
+	return bb
+}
+
+// WithSECPMessageVersioned adds a SECP message, expecting the result for the network version of the driver.
+func (bb *BlockBuilder) WithSECPMessageVersioned(bm *types.Message, expected *VersionedResult) *BlockBuilder {
+	res := expected.At(bb.TD.ExeCtx.NetworkVersion)
+	bb.secpMsgs = append(bb.secpMsgs, bb.toSignedMessage(bm))
+	bb.addResult(res.ExitCode, res.ReturnVal)
+	return bb
+}
+
 func (bb *BlockBuilder) WithTicketCount(count int64) *BlockBuilder {
 	bb.ticketCount = count
 	return bb
@@ -0,0 +1,86 @@
+package drivers
+
+import (
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	cid "github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// ApplyEmptyTipSets applies a tipset holding a single empty block, mined by the builtin miner, at each epoch from the
+// current epoch up to and including `through`, advancing the epoch of the driver past it. Tipsets crossing the
+// height of an upgrade in the driver's schedule migrate the state.
+func (td *TestDriver) ApplyEmptyTipSets(through abi_spec.ChainEpoch) []types.ApplyTipSetResult {
+	var results []types.ApplyTipSetResult
+	for ; td.ExeCtx.Epoch <= through; td.ExeCtx.Epoch++ {
+		results = append(results, NewTipSetMessageBuilder(td).
+			WithBlockBuilder(NewBlockBuilder(td, td.ExeCtx.Miner)).
+			Apply())
+	}
+	return results
+}
+
+// ActorSnapshot holds the fields of an actor compared across a state migration or a message.
+type ActorSnapshot struct {
+	Code       cid.Cid
+	Head       cid.Cid
+	Balance    abi_spec.TokenAmount
+	CallSeqNum uint64
+}
+
+func snapshotActor(actr state.Actor) ActorSnapshot {
+	return ActorSnapshot{
+		Code:       actr.Code(),
+		Head:       actr.Head(),
+		Balance:    actr.Balance(),
+		CallSeqNum: actr.CallSeqNum(),
+	}
+}
+
+// SnapshotActors returns the current code, balance and call sequence number of each actor in `addrs`.
+func (td *TestDriver) SnapshotActors(addrs ...address.Address) map[address.Address]ActorSnapshot {
+	snapshot := make(map[address.Address]ActorSnapshot, len(addrs))
+	for _, addr := range addrs {
+		actr, err := td.State().Actor(addr)
+		require.NoError(td.T, err)
+		snapshot[addr] = snapshotActor(actr)
+	}
+	return snapshot
+}
+
+// AssertActorsMigrated asserts each actor in `before` still exists with the same balance and call sequence number,
+// and with its code replaced according to `codes`, e.g. as returned by actors.MigratedCodes. Codes missing from
+// `codes` are expected to be unchanged.
+func (td *TestDriver) AssertActorsMigrated(before map[address.Address]ActorSnapshot, codes map[cid.Cid]cid.Cid) {
+	for addr, prev := range before {
+		actr, err := td.State().Actor(addr)
+		if !assert.NoError(td.T, err, "actor %s does not exist after upgrade", addr) {
+			continue
+		}
+		expectedCode := prev.Code
+		if migrated, ok := codes[prev.Code]; ok {
+			expectedCode = migrated
+		}
+		assert.Equal(td.T, expectedCode, actr.Code(), "actor %s Expected Code: %s Actual Code: %s", addr, expectedCode, actr.Code())
+		assert.Equal(td.T, prev.Balance.String(), actr.Balance().String(), "actor %s Expected Balance: %s Actual Balance: %s", addr, prev.Balance, actr.Balance())
+		assert.Equal(td.T, prev.CallSeqNum, actr.CallSeqNum(), "actor %s Expected CallSeqNum: %d Actual CallSeqNum: %d", addr, prev.CallSeqNum, actr.CallSeqNum())
+	}
+}
+
+// followUpgrades switches the driver to the actors version of the state after the tipset applied at the current
+// epoch, which migrated the state if it crossed an upgrade changing the actors version.
+func (td *TestDriver) followUpgrades() {
+	v := td.upgrades.ActorsVersion(td.genesisActors, td.ExeCtx.Epoch)
+	if v == td.Actors().Version() {
+		return
+	}
+	av, err := actors.Get(v)
+	require.NoError(td.T, err)
+	td.av = av
+	td.MessageProducer.SetActors(av)
+}
@@ -0,0 +1,51 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/chain/actors/actorstest"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+func TestFollowUpgrades(t *testing.T) {
+	double := actorstest.New(actors.V0, actors.Version0+1)
+	actors.Register(double)
+
+	td := &TestDriver{
+		StateDriver:     &StateDriver{tb: t, av: actors.V0},
+		T:               t,
+		MessageProducer: chain.NewMessageProducer(actors.V0, big_spec.Zero(), big_spec.Zero(), 0),
+		ExeCtx:          &types.ExecutionContext{},
+		upgrades: state.UpgradeSchedule{
+			{Height: 2, Network: network.Version1, Actors: actors.Version0},
+			{Height: 4, Network: network.Version2, Actors: double.Version()},
+		},
+		genesisActors: actors.Version0,
+	}
+	for _, tc := range []struct {
+		epoch  abi_spec.ChainEpoch
+		actors actors.Actors
+	}{
+		{epoch: 1, actors: actors.V0},
+		{epoch: 2, actors: actors.V0},
+		{epoch: 4, actors: double},
+		{epoch: 5, actors: double},
+	} {
+		td.ExeCtx.Epoch = tc.epoch
+		td.followUpgrades()
+		assert.Equal(t, tc.actors, td.Actors(), "epoch %d", tc.epoch)
+		// messages are produced for the actors the driver follows.
+		expected, _ := tc.actors.MethodNum(tc.actors.Codes().Account, "PubkeyAddress")
+		msg := td.MessageProducer.AccountPubkeyAddress(address.Undef, address.Undef, nil)
+		assert.Equal(t, expected, msg.Method, "epoch %d", tc.epoch)
+	}
+}
@@ -0,0 +1,74 @@
+package drivers
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+// NetworkVersionRange is an inclusive range of network versions.
+type NetworkVersionRange struct {
+	From network.Version
+	To   network.Version
+}
+
+// NetworkVersions returns the range of versions from `from` to `to` inclusive.
+func NetworkVersions(from, to network.Version) NetworkVersionRange {
+	return NetworkVersionRange{From: from, To: to}
+}
+
+// Run runs `f` as a subtest named after each network version in the range, e.g. "nv3". The subtest builds its driver
+// with TestDriverBuilder.WithNetworkVersion so versions the implementation does not support are skipped.
+func (r NetworkVersionRange) Run(t *testing.T, f func(t *testing.T, nv network.Version)) {
+	if r.From > r.To {
+		t.Fatalf("invalid network version range %d-%d", r.From, r.To)
+	}
+	for nv := r.From; nv <= r.To; nv++ {
+		nv := nv
+		t.Run(fmt.Sprintf("nv%d", nv), func(t *testing.T) {
+			f(t, nv)
+		})
+	}
+}
+
+// VersionedResult is the expected outcome of a message whose result changed at one or more network versions.
+type VersionedResult struct {
+	since   []network.Version
+	results map[network.Version]ExpectedResult
+}
+
+// ExpectResult returns a VersionedResult expecting `code` and `retval` under every network version, until
+// overridden with Since.
+func ExpectResult(code exitcode.ExitCode, retval []byte) *VersionedResult {
+	return &VersionedResult{
+		since:   []network.Version{types.DefaultNetworkVersion},
+		results: map[network.Version]ExpectedResult{types.DefaultNetworkVersion: {ExitCode: code, ReturnVal: retval}},
+	}
+}
+
+// Since expects `code` and `retval` from network version `nv` on, until overridden for a later version.
+func (vr *VersionedResult) Since(nv network.Version, code exitcode.ExitCode, retval []byte) *VersionedResult {
+	if _, ok := vr.results[nv]; !ok {
+		vr.since = append(vr.since, nv)
+		sort.Slice(vr.since, func(i, j int) bool { return vr.since[i] < vr.since[j] })
+	}
+	vr.results[nv] = ExpectedResult{ExitCode: code, ReturnVal: retval}
+	return vr
+}
+
+// At returns the result expected under network version `nv`.
+func (vr *VersionedResult) At(nv network.Version) ExpectedResult {
+	res := vr.results[vr.since[0]]
+	for _, v := range vr.since {
+		if v > nv {
+			break
+		}
+		res = vr.results[v]
+	}
+	return res
+}
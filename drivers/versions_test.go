@@ -0,0 +1,64 @@
+package drivers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/chain-validation/chain/types"
+)
+
+func TestVersionedResultAt(t *testing.T) {
+	// overrides are added out of order, each applying from its version up to the next.
+	vr := ExpectResult(exitcode.Ok, []byte{0}).
+		Since(network.Version3, exitcode.ErrForbidden, nil).
+		Since(network.Version1, exitcode.ErrIllegalArgument, []byte{1})
+
+	for _, tc := range []struct {
+		nv       network.Version
+		expected ExpectedResult
+	}{
+		{network.Version0, ExpectedResult{ExitCode: exitcode.Ok, ReturnVal: []byte{0}}},
+		{network.Version1, ExpectedResult{ExitCode: exitcode.ErrIllegalArgument, ReturnVal: []byte{1}}},
+		{network.Version2, ExpectedResult{ExitCode: exitcode.ErrIllegalArgument, ReturnVal: []byte{1}}},
+		{network.Version3, ExpectedResult{ExitCode: exitcode.ErrForbidden}},
+		{network.Version3 + 1, ExpectedResult{ExitCode: exitcode.ErrForbidden}},
+	} {
+		assert.Equal(t, tc.expected, vr.At(tc.nv), "network version %d", tc.nv)
+	}
+}
+
+func TestVersionedResultSinceReplaces(t *testing.T) {
+	vr := ExpectResult(exitcode.Ok, nil).
+		Since(network.Version2, exitcode.ErrForbidden, nil).
+		Since(network.Version2, exitcode.ErrNotFound, nil).
+		Since(types.DefaultNetworkVersion, exitcode.ErrIllegalState, nil)
+
+	assert.Equal(t, exitcode.ErrIllegalState, vr.At(network.Version0).ExitCode)
+	assert.Equal(t, exitcode.ErrIllegalState, vr.At(network.Version1).ExitCode)
+	assert.Equal(t, exitcode.ErrNotFound, vr.At(network.Version2).ExitCode)
+}
+
+func TestNetworkVersionRangeRun(t *testing.T) {
+	var ran []network.Version
+	NetworkVersions(network.Version1, network.Version3).Run(t, func(t *testing.T, nv network.Version) {
+		assert.Equal(t, fmt.Sprintf("TestNetworkVersionRangeRun/nv%d", nv), t.Name())
+		ran = append(ran, nv)
+	})
+	assert.Equal(t, []network.Version{network.Version1, network.Version2, network.Version3}, ran)
+}
+
+func TestBuilderCopy(t *testing.T) {
+	b := NewBuilder(context.Background(), nil).WithActorState(ActorState{Balance: big_spec.NewInt(1)})
+	c := b.Copy().WithNetworkVersion(network.Version3).WithActorState(ActorState{Balance: big_spec.NewInt(2)})
+
+	assert.Equal(t, types.DefaultNetworkVersion, b.networkVersion)
+	assert.Len(t, b.actorStates, 1)
+	assert.Equal(t, network.Version3, c.networkVersion)
+	assert.Len(t, c.actorStates, 2)
+}
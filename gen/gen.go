@@ -1,12 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"flag"
 	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path"
+	"path/filepath"
 	"reflect"
-	"runtime"
+	"sort"
 	"strings"
+	"text/template"
 
-	"github.com/dave/jennifer/jen"
 	"github.com/filecoin-project/specs-actors/actors/builtin"
 	"github.com/filecoin-project/specs-actors/actors/builtin/account"
 	"github.com/filecoin-project/specs-actors/actors/builtin/cron"
@@ -17,142 +24,249 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/builtin/paych"
 	"github.com/filecoin-project/specs-actors/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
 	"github.com/filecoin-project/specs-actors/actors/puppet"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
 )
 
-// This is a tool used to save some time writing all the message builder methods for actors.
-// It produces code that does NOT compile but saves a lot of typing.
+// This tool writes the message producers of the builtin actors, one file per actor, by reflecting over the methods
+// each actor exports. It is run by go generate in the chain package:
+//
+//	go generate ./chain
+//
+// For each exported method it writes a producer building a message that calls the method, and, if the method returns
+// a value, a function decoding the value from the return value of a receipt. Producers of builtin actors look up the
+// method number in the actors version of the message producer, by the name of the method.
 func main() {
+	out := flag.String("out", ".", "directory to write the producers to")
+	flag.Parse()
 
-	accountExports := account.Actor{}.Exports()
-	accountDetails := ParseGenerationFields("account_messages", "Account", accountExports, builtin.MethodsAccount)
-	f := jen.NewFile(accountDetails.file)
-	MakeMethods(f, accountDetails)
-	fmt.Printf("%#v", f)
-
-	cronExports := cron.Actor{}.Exports()
-	details := ParseGenerationFields("cron_messages", "Cron", cronExports, builtin.MethodsCron)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	initExports := init_.Actor{}.Exports()
-	details = ParseGenerationFields("init_messages", "Init", initExports, builtin.MethodsInit)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	marketExports := market.Actor{}.Exports()
-	details = ParseGenerationFields("market_messages", "Market", marketExports, builtin.MethodsMarket)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	minerExports := miner.Actor{}.Exports()
-	details = ParseGenerationFields("miner_messages", "Miner", minerExports, builtin.MethodsMiner)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	multisigExports := multisig.Actor{}.Exports()
-	details = ParseGenerationFields("multisig_messages", "Multisig", multisigExports, builtin.MethodsMultisig)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	paychExports := paych.Actor{}.Exports()
-	details = ParseGenerationFields("paych_messages", "Paych", paychExports, builtin.MethodsPaych)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	powerExports := power.Actor{}.Exports()
-	details = ParseGenerationFields("power_messages", "Power", powerExports, builtin.MethodsPower)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	rewardExports := reward.Actor{}.Exports()
-	details = ParseGenerationFields("reward_messages", "Reward", rewardExports, builtin.MethodsReward)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
-
-	puppetExports := puppet.Actor{}.Exports()
-	details = ParseGenerationFields("puppet_messages", "Puppet", puppetExports, puppet.MethodsPuppet)
-	f = jen.NewFile(details.file)
-	MakeMethods(f, details)
-	fmt.Printf("%#v", f)
+	for _, a := range builtinActors {
+		src, err := generate(a)
+		if err != nil {
+			log.Fatalf("generating %s: %v", a.file, err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(*out, a.file), src, 0644); err != nil {
+			log.Fatalf("writing %s: %v", a.file, err)
+		}
+	}
 }
 
-type GenDetails struct {
-	file  string
-	pairs []MethodParam
+// actor is a builtin actor to write producers for.
+type actor struct {
+	file string
+	// prefix starts the names of the producers.
+	prefix  string
+	exports []interface{}
+	// methods is the struct of method numbers of the actor, e.g. builtin.MethodsMiner, declared as `methodsVar` in
+	// the package at `methodsPath`.
+	methods     interface{}
+	methodsPath string
+	methodsVar  string
+	// codesField is the field of actors.Codes holding the code of the actor, whose methods the producers then number
+	// for the actors version of the message producer. Producers of actors without one use the numbers of `methods`.
+	codesField string
+}
+
+var builtinActors = []actor{
+	{"account_messages.go", "Account", account.Actor{}.Exports(), builtin.MethodsAccount, builtinPath, "MethodsAccount", "Account"},
+	{"cron_messages.go", "Cron", cron.Actor{}.Exports(), builtin.MethodsCron, builtinPath, "MethodsCron", "Cron"},
+	{"init_messages.go", "Init", init_.Actor{}.Exports(), builtin.MethodsInit, builtinPath, "MethodsInit", "Init"},
+	{"market_messages.go", "Market", market.Actor{}.Exports(), builtin.MethodsMarket, builtinPath, "MethodsMarket", "Market"},
+	{"miner_messages.go", "Miner", miner.Actor{}.Exports(), builtin.MethodsMiner, builtinPath, "MethodsMiner", "Miner"},
+	{"multisig_messages.go", "Multisig", multisig.Actor{}.Exports(), builtin.MethodsMultisig, builtinPath, "MethodsMultisig", "Multisig"},
+	{"paych_messages.go", "Paych", paych.Actor{}.Exports(), builtin.MethodsPaych, builtinPath, "MethodsPaych", "PaymentChannel"},
+	{"power_messages.go", "Power", power.Actor{}.Exports(), builtin.MethodsPower, builtinPath, "MethodsPower", "Power"},
+	{"puppet_messages.go", "Puppet", puppet.Actor{}.Exports(), puppet.MethodsPuppet, puppetPath, "MethodsPuppet", ""},
+	{"reward_messages.go", "Reward", reward.Actor{}.Exports(), builtin.MethodsReward, builtinPath, "MethodsReward", "Reward"},
+	{"verifreg_messages.go", "Verifreg", verifreg.Actor{}.Exports(), builtin.MethodsVerifiedRegistry, builtinPath, "MethodsVerifiedRegistry", "VerifiedReg"},
+}
+
+const (
+	builtinPath = "github.com/filecoin-project/specs-actors/actors/builtin"
+	puppetPath  = "github.com/filecoin-project/specs-actors/actors/puppet"
+	addressPath = "github.com/filecoin-project/go-address"
+	typesPath   = "github.com/filecoin-project/chain-validation/chain/types"
+	modulePath  = "github.com/filecoin-project/chain-validation/"
+)
+
+// importAliases are the names packages are imported as where they differ from the package name.
+var importAliases = map[string]string{
+	builtinPath: "builtin_spec",
+	"github.com/filecoin-project/specs-actors/actors/builtin/init": "init_",
+	"github.com/whyrusleeping/cbor-gen":                            "cbg",
+}
+
+var emptyValueType = reflect.TypeOf(&adt.EmptyValue{})
+
+// producerFile is the content of a file of producers.
+type producerFile struct {
+	Imports []importSpec
+	// ModuleImports are the imports of packages of this module, grouped after the others.
+	ModuleImports []importSpec
+	Methods       []producerMethod
 }
 
-type MethodParam struct {
-	method        string
-	methodPrefix  string
-	methodNumbers []string
-	params        string
+type importSpec struct {
+	Alias string // empty when the package is imported under its name
+	Path  string
 }
 
-func ParseGenerationFields(file string, methodPrefix string, exports []interface{}, methods interface{}) GenDetails {
-	details := GenDetails{
-		file: file,
+func (i importSpec) String() string {
+	if i.Alias == "" {
+		return fmt.Sprintf("%q", i.Path)
 	}
-	for _, m := range exports {
-		if m == nil {
-			continue
-		}
+	return fmt.Sprintf("%s %q", i.Alias, i.Path)
+}
 
-		// ignore things that are not functions
-		meth := reflect.ValueOf(m)
-		t := meth.Type()
-		if t.Kind() != reflect.Func {
-			continue
-		}
+type producerMethod struct {
+	// Producer is the name of the producer.
+	Producer string
+	// Method is the expression of the method number.
+	Method string
+	Params string
+	// Return is the type of the value returned, empty when the method returns nothing.
+	Return string
+}
 
-		methodPkgPath := runtime.FuncForPC(reflect.ValueOf(m).Pointer()).Name()
-		tokens := strings.Split(methodPkgPath, "/")
+// ReturnElem is the type a pointer to which is returned.
+func (m producerMethod) ReturnElem() string {
+	return strings.TrimPrefix(m.Return, "*")
+}
 
-		pkgStructMethod := strings.Split(tokens[len(tokens)-1], ".")
+var producerTemplate = template.Must(template.New("producers").Parse(`// Code generated by github.com/filecoin-project/chain-validation/gen. DO NOT EDIT.
 
-		method := strings.TrimRight(pkgStructMethod[2], "-fm")
+package chain
 
-		methodParam := t.In(1).String()
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+{{range .ModuleImports}}
+	{{.}}
+{{- end}}
+)
+{{range .Methods}}
+func (mp *MessageProducer) {{.Producer}}(from, to address.Address, params {{.Params}}, opts ...MsgOpt) *types.Message {
+	ser := MustSerialize(params)
+	return mp.Build(from, to, {{.Method}}, ser, opts...)
+}
+{{- if .Return}}
+
+// Decode{{.Producer}}Return decodes the value returned by {{.Producer}} messages.
+func Decode{{.Producer}}Return(ret []byte) ({{.Return}}, error) {
+	out := new({{.ReturnElem}})
+	if err := Deserialize(ret, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{- end}}
+{{end}}`))
 
-		v := reflect.ValueOf(methods)
-		typeOfS := v.Type()
-		values := make([]string, v.NumField())
-		for i := 0; i < v.NumField(); i++ {
-			values[i] = typeOfS.Field(i).Name
+// generate returns the formatted source of the producers of `a`.
+func generate(a actor) ([]byte, error) {
+	f, err := describe(a)
+	if err != nil {
+		return nil, err
+	}
+	return render(f)
+}
+
+func render(f *producerFile) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := producerTemplate.Execute(&buf, f); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// describe reflects over the exports of `a`, failing if an exported method has no method number of the same name.
+func describe(a actor) (*producerFile, error) {
+	imports := map[string]string{
+		addressPath: "",
+		typesPath:   "",
+	}
+	// qualify returns the name `t` is referred to by in the producers, importing its package.
+	qualify := func(t reflect.Type) string {
+		ptr := ""
+		if t.Kind() == reflect.Ptr {
+			ptr, t = "*", t.Elem()
 		}
+		pkg := strings.SplitN(t.String(), ".", 2)[0]
+		alias, ok := importAliases[t.PkgPath()]
+		if !ok {
+			alias = pkg
+		}
+		if alias == pkg {
+			imports[t.PkgPath()] = ""
+		} else {
+			imports[t.PkgPath()] = alias
+		}
+		return ptr + alias + "." + t.Name()
+	}
 
-		details.pairs = append(details.pairs, MethodParam{
-			method:        method,
-			methodNumbers: values,
-			methodPrefix:  methodPrefix,
-			params:        methodParam,
-		})
+	// the method numbers are declared in a struct of anonymous type, qualified by the package declaring them.
+	methods := reflect.ValueOf(a.methods)
+	methodsPkg, ok := importAliases[a.methodsPath]
+	if !ok {
+		methodsPkg = path.Base(a.methodsPath)
 	}
-	return details
+	if a.codesField == "" {
+		imports[a.methodsPath] = importAliases[a.methodsPath]
+	}
+	methodsExpr := methodsPkg + "." + a.methodsVar
+
+	f := &producerFile{}
+	for num, export := range a.exports {
+		if export == nil {
+			continue
+		}
+		name := actors.ExportedMethodName(export)
+		field, ok := methodNumberField(methods, num)
+		if !ok {
+			return nil, fmt.Errorf("%s exports %s as method %d, which has no method number", a.prefix, name, num)
+		}
+		if field != name {
+			return nil, fmt.Errorf("%s exports %s as method %d, numbered %s", a.prefix, name, num, field)
+		}
+
+		fn := reflect.TypeOf(export)
+		m := producerMethod{
+			Producer: a.prefix + name,
+			Method:   methodsExpr + "." + field,
+			Params:   qualify(fn.In(1)),
+		}
+		if a.codesField != "" {
+			m.Method = fmt.Sprintf("mp.methodNum(mp.av.Codes().%s, %q)", a.codesField, name)
+		}
+		if ret := fn.Out(0); ret != emptyValueType {
+			m.Return = qualify(ret)
+		}
+		f.Methods = append(f.Methods, m)
+	}
+
+	for p, alias := range imports {
+		spec := importSpec{Alias: alias, Path: p}
+		if strings.HasPrefix(p, modulePath) {
+			f.ModuleImports = append(f.ModuleImports, spec)
+		} else {
+			f.Imports = append(f.Imports, spec)
+		}
+	}
+	sort.Slice(f.Imports, func(i, j int) bool { return f.Imports[i].Path < f.Imports[j].Path })
+	sort.Slice(f.ModuleImports, func(i, j int) bool { return f.ModuleImports[i].Path < f.ModuleImports[j].Path })
+	return f, nil
 }
 
-func MakeMethods(jenFile *jen.File, details GenDetails) {
-	for i, d := range details.pairs {
-		jenFile.Func().Params(
-			jen.Id("mp").Id("*MessageProducer"),
-		).Id(fmt.Sprintf("%s%s", d.methodPrefix, d.method)).Params(
-			jen.Id("from"),
-			jen.Id("to").Id("address.Address"),
-			jen.Id("params").Id(d.params),
-			jen.Id("opts").Id("...MsgOpt"),
-		).Params(
-			jen.Id("*types.Message"),
-		).Block(
-			jen.Id("ser").Op(":=").Id("MustSerialize").Call(jen.Id("params")),
-			jen.Return(jen.Id("mp.Build").Params(jen.Id("to"), jen.Id("from"), jen.Id(fmt.Sprintf("builtin_spec.Methods%s.%s", d.methodPrefix, d.methodNumbers[i])), jen.Id("ser"), jen.Id("opts..."))),
-		)
+// methodNumberField returns the name of the field of `methods` holding `num`.
+func methodNumberField(methods reflect.Value, num int) (string, bool) {
+	for i := 0; i < methods.NumField(); i++ {
+		if methods.Field(i).Uint() == uint64(num) {
+			return methods.Type().Field(i).Name, true
+		}
 	}
+	return "", false
 }
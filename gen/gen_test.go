@@ -0,0 +1,41 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+)
+
+func TestMethodNumbersMatchExports(t *testing.T) {
+	for _, a := range builtinActors {
+		methods := reflect.ValueOf(a.methods)
+		for num, export := range a.exports {
+			if export == nil {
+				continue
+			}
+			name := actors.ExportedMethodName(export)
+			field, ok := methodNumberField(methods, num)
+			if assert.True(t, ok, "%s exports %s as method %d, which has no method number", a.prefix, name, num) {
+				assert.Equal(t, name, field, "%s exports %s as method %d, numbered %s", a.prefix, name, num, field)
+			}
+		}
+	}
+}
+
+// The producers in the chain package must be those generated from the exports of the actors, so that methods added
+// to the actors are not missed.
+func TestProducersUpToDate(t *testing.T) {
+	for _, a := range builtinActors {
+		expected, err := generate(a)
+		require.NoError(t, err, a.file)
+		actual, err := ioutil.ReadFile(filepath.Join("..", "chain", a.file))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(actual), "%s is out of date, run go generate ./chain", a.file)
+	}
+}
@@ -1,15 +1,14 @@
 module github.com/filecoin-project/chain-validation
 
-go 1.13
+go 1.16
 
 require (
-	github.com/dave/jennifer v1.4.0
 	github.com/filecoin-project/filecoin-ffi v0.30.4-0.20200716204036-cddc56607e1d
 	github.com/filecoin-project/go-address v0.0.3
 	github.com/filecoin-project/go-bitfield v0.2.0
 	github.com/filecoin-project/go-crypto v0.0.0-20191218222705-effae4ea9f03
 	github.com/filecoin-project/go-fil-commcid v0.0.0-20200716160307-8f644712406f
-	github.com/filecoin-project/go-state-types v0.0.0-20200905071437-95828685f9df
+	github.com/filecoin-project/go-state-types v0.0.0-20200928172055-2df22083d8ab
 	github.com/filecoin-project/specs-actors v0.9.6
 	github.com/gorilla/rpc v1.2.0
 	github.com/ipfs/go-block-format v0.0.2
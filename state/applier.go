@@ -2,9 +2,11 @@ package state
 
 import (
 	"context"
+	"errors"
 
 	"github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/network"
 
 	"github.com/filecoin-project/chain-validation/chain/types"
 )
@@ -16,6 +18,19 @@ type Applier interface {
 	ApplyTipSetMessages(epoch abi.ChainEpoch, blocks []types.BlockMessagesInfo, rnd RandomnessSource) (types.ApplyTipSetResult, error)
 }
 
+// ErrUnsupportedNetworkVersion is returned by appliers asked to execute messages under a network version they don't
+// implement. Tests selecting such a version are skipped rather than failed.
+var ErrUnsupportedNetworkVersion = errors.New("unsupported network version")
+
+// VersionedApplier is implemented by appliers that execute messages under more than one network version.
+// The network version of the execution context is set before each message or tipset is applied.
+type VersionedApplier interface {
+	Applier
+	// SetNetworkVersion selects the rules subsequent messages are executed under, returning an error wrapping
+	// ErrUnsupportedNetworkVersion if the applier does not implement `nv`.
+	SetNetworkVersion(nv network.Version) error
+}
+
 // RandomnessSource provides randomness to actors.
 type RandomnessSource interface {
 	Randomness(ctx context.Context, tag crypto.DomainSeparationTag, epoch abi.ChainEpoch, entropy []byte) (abi.Randomness, error)
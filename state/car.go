@@ -0,0 +1,274 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/multiformats/go-varint"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// ErrUnsupported is returned when a wrapper lacks an optional capability that has no generic fallback.
+var ErrUnsupported = errors.New("unsupported by the state wrapper")
+
+// CARImporter is implemented by wrappers that import CAR files into their store natively.
+type CARImporter interface {
+	// ImportCAR imports the blocks of the CAR file read from `r`, returning its root.
+	ImportCAR(r io.Reader) (root cid.Cid, err error)
+}
+
+// CARExporter is implemented by wrappers that export CAR files from their store natively.
+type CARExporter interface {
+	// ExportCAR writes the DAG rooted at `root` to `w` as a CAR file.
+	ExportCAR(root cid.Cid, w io.Writer) error
+}
+
+// RootSetter is implemented by wrappers that can adopt a state tree already in their store, e.g. one imported from
+// a CAR file.
+type RootSetter interface {
+	// SetRoot replaces the state tree with the one rooted at `root`.
+	SetRoot(root cid.Cid) error
+}
+
+// ImportCAR imports the blocks of the CAR file read from `r` into the store of `vmw`, returning the root of the file.
+// Wrappers that do not implement CARImporter have the blocks put one at a time through StorePut, which only accepts
+// DAG-CBOR blocks hashed the way the wrapper hashes them.
+func ImportCAR(vmw VMWrapper, r io.Reader) (cid.Cid, error) {
+	if imp, ok := vmw.(CARImporter); ok {
+		return imp.ImportCAR(r)
+	}
+
+	br := bufio.NewReader(r)
+	root, err := readCARHeader(br)
+	if err != nil {
+		return cid.Undef, err
+	}
+	for {
+		c, data, err := readCARBlock(br)
+		if err == io.EOF {
+			return root, nil
+		}
+		if err != nil {
+			return cid.Undef, err
+		}
+		if c.Type() != cid.DagCBOR {
+			return cid.Undef, xerrors.Errorf("cannot import block %s: codec %x is not DAG-CBOR", c, c.Type())
+		}
+		put, err := vmw.StorePut(rawBlock(data))
+		if err != nil {
+			return cid.Undef, xerrors.Errorf("importing block %s: %w", c, err)
+		}
+		if !put.Equals(c) {
+			return cid.Undef, xerrors.Errorf("imported block %s was stored as %s", c, put)
+		}
+	}
+}
+
+// ExportCAR writes the DAG rooted at `root` in the store of `vmw` to `w` as a CAR file. Wrappers that do not implement
+// CARExporter have the DAG walked depth first through StoreGet, following the links of DAG-CBOR blocks.
+func ExportCAR(vmw VMWrapper, root cid.Cid, w io.Writer) error {
+	if exp, ok := vmw.(CARExporter); ok {
+		return exp.ExportCAR(root, w)
+	}
+
+	if err := writeCARHeader(w, root); err != nil {
+		return err
+	}
+	seen := cid.NewSet()
+	var walk func(c cid.Cid) error
+	walk = func(c cid.Cid) error {
+		// identity hashed blocks are inlined in their CID and are not held by stores.
+		if c.Prefix().MhType == multihash.IDENTITY || !seen.Visit(c) {
+			return nil
+		}
+		var blk rawBlock
+		if err := vmw.StoreGet(c, &blk); err != nil {
+			return xerrors.Errorf("exporting block %s: %w", c, err)
+		}
+		if err := writeCARBlock(w, c, blk); err != nil {
+			return err
+		}
+		if c.Type() != cid.DagCBOR {
+			return nil
+		}
+		var links []cid.Cid
+		if err := cbg.ScanForLinks(bytes.NewReader(blk), func(l cid.Cid) {
+			links = append(links, l)
+		}); err != nil {
+			return xerrors.Errorf("scanning block %s for links: %w", c, err)
+		}
+		for _, l := range links {
+			if err := walk(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(root)
+}
+
+// SetRoot replaces the state tree of `vmw` with the one rooted at `root`, returning ErrUnsupported if the wrapper
+// does not implement RootSetter.
+func SetRoot(vmw VMWrapper, root cid.Cid) error {
+	rs, ok := vmw.(RootSetter)
+	if !ok {
+		return xerrors.Errorf("setting state root: %w", ErrUnsupported)
+	}
+	return rs.SetRoot(root)
+}
+
+// rawBlock passes the encoded bytes of a block through StoreGet and StorePut unchanged.
+type rawBlock []byte
+
+func (b rawBlock) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write(b)
+	return err
+}
+
+func (b *rawBlock) UnmarshalCBOR(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	*b = data
+	return nil
+}
+
+// A CARv1 file is a header, the DAG-CBOR map {"roots": [root], "version": 1}, followed by blocks, each a CID followed
+// by the block data. The header and every block are prefixed with their length as an unsigned varint.
+
+func writeCARHeader(w io.Writer, root cid.Cid) error {
+	var hdr bytes.Buffer
+	if err := cbg.WriteMajorTypeHeader(&hdr, cbg.MajMap, 2); err != nil {
+		return err
+	}
+	if err := writeCBORString(&hdr, "roots"); err != nil {
+		return err
+	}
+	if err := cbg.WriteMajorTypeHeader(&hdr, cbg.MajArray, 1); err != nil {
+		return err
+	}
+	if err := cbg.WriteCid(&hdr, root); err != nil {
+		return err
+	}
+	if err := writeCBORString(&hdr, "version"); err != nil {
+		return err
+	}
+	if err := cbg.WriteMajorTypeHeader(&hdr, cbg.MajUnsignedInt, 1); err != nil {
+		return err
+	}
+	return writeCARSection(w, hdr.Bytes())
+}
+
+func writeCBORString(w io.Writer, s string) error {
+	if err := cbg.WriteMajorTypeHeader(w, cbg.MajTextString, uint64(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func writeCARBlock(w io.Writer, c cid.Cid, data []byte) error {
+	return writeCARSection(w, append(c.Bytes(), data...))
+}
+
+func writeCARSection(w io.Writer, data []byte) error {
+	if _, err := w.Write(varint.ToUvarint(uint64(len(data)))); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readCARSection(br *bufio.Reader) ([]byte, error) {
+	l, err := varint.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, l)
+	if _, err := io.ReadFull(br, data); err != nil {
+		return nil, xerrors.Errorf("reading CAR section: %w", err)
+	}
+	return data, nil
+}
+
+// readCARHeader reads the header of a CAR file, returning its only root.
+func readCARHeader(br *bufio.Reader) (cid.Cid, error) {
+	data, err := readCARSection(br)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("reading CAR header: %w", err)
+	}
+	hdr := bytes.NewReader(data)
+	maj, n, err := cbg.CborReadHeader(hdr)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if maj != cbg.MajMap {
+		return cid.Undef, xerrors.Errorf("CAR header is not a map")
+	}
+
+	var roots []cid.Cid
+	var version uint64
+	for i := uint64(0); i < n; i++ {
+		key, err := cbg.ReadString(hdr)
+		if err != nil {
+			return cid.Undef, err
+		}
+		switch key {
+		case "roots":
+			maj, l, err := cbg.CborReadHeader(hdr)
+			if err != nil {
+				return cid.Undef, err
+			}
+			if maj != cbg.MajArray {
+				return cid.Undef, xerrors.Errorf("CAR header roots are not an array")
+			}
+			for j := uint64(0); j < l; j++ {
+				root, err := cbg.ReadCid(hdr)
+				if err != nil {
+					return cid.Undef, err
+				}
+				roots = append(roots, root)
+			}
+		case "version":
+			maj, v, err := cbg.CborReadHeader(hdr)
+			if err != nil {
+				return cid.Undef, err
+			}
+			if maj != cbg.MajUnsignedInt {
+				return cid.Undef, xerrors.Errorf("CAR header version is not an unsigned integer")
+			}
+			version = v
+		default:
+			return cid.Undef, xerrors.Errorf("unexpected CAR header field %q", key)
+		}
+	}
+	if version != 1 {
+		return cid.Undef, xerrors.Errorf("unsupported CAR version %d", version)
+	}
+	if len(roots) != 1 {
+		return cid.Undef, xerrors.Errorf("CAR file has %d roots, expected 1", len(roots))
+	}
+	return roots[0], nil
+}
+
+func readCARBlock(br *bufio.Reader) (cid.Cid, []byte, error) {
+	if _, err := br.Peek(1); err == io.EOF {
+		return cid.Undef, nil, io.EOF
+	}
+	data, err := readCARSection(br)
+	if err != nil {
+		return cid.Undef, nil, xerrors.Errorf("reading CAR block: %w", err)
+	}
+	n, c, err := cid.CidFromBytes(data)
+	if err != nil {
+		return cid.Undef, nil, xerrors.Errorf("reading CAR block CID: %w", err)
+	}
+	return c, data[n:], nil
+}
@@ -0,0 +1,269 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// memWrapper is a VMWrapper holding only a store of DAG-CBOR blocks, keyed by their CIDs.
+type memWrapper struct {
+	blocks map[cid.Cid][]byte
+}
+
+func newMemWrapper() *memWrapper {
+	return &memWrapper{blocks: make(map[cid.Cid][]byte)}
+}
+
+var cborPrefix = cid.Prefix{Version: 1, Codec: cid.DagCBOR, MhType: multihash.SHA2_256, MhLength: -1}
+
+func (w *memWrapper) NewVM()        {}
+func (w *memWrapper) Root() cid.Cid { return cid.Undef }
+
+func (w *memWrapper) StoreGet(key cid.Cid, out runtime.CBORUnmarshaler) error {
+	data, ok := w.blocks[key]
+	if !ok {
+		return xerrors.Errorf("block %s not found", key)
+	}
+	return out.UnmarshalCBOR(bytes.NewReader(data))
+}
+
+func (w *memWrapper) StorePut(value runtime.CBORMarshaler) (cid.Cid, error) {
+	var buf bytes.Buffer
+	if err := value.MarshalCBOR(&buf); err != nil {
+		return cid.Undef, err
+	}
+	c, err := cborPrefix.Sum(buf.Bytes())
+	if err != nil {
+		return cid.Undef, err
+	}
+	w.blocks[c] = buf.Bytes()
+	return c, nil
+}
+
+func (w *memWrapper) Actor(address.Address) (Actor, error) {
+	return nil, ErrUnsupported
+}
+
+func (w *memWrapper) SetActorState(address.Address, abi.TokenAmount, runtime.CBORMarshaler) (Actor, error) {
+	return nil, ErrUnsupported
+}
+
+func (w *memWrapper) CreateActor(cid.Cid, address.Address, abi.TokenAmount, runtime.CBORMarshaler) (Actor, address.Address, error) {
+	return nil, address.Undef, ErrUnsupported
+}
+
+// nativeCARWrapper imports CAR files natively, recording the bytes it was given.
+type nativeCARWrapper struct {
+	memWrapper
+	imported []byte
+}
+
+func (w *nativeCARWrapper) ImportCAR(r io.Reader) (cid.Cid, error) {
+	var err error
+	w.imported, err = ioutil.ReadAll(r)
+	return cid.Undef, err
+}
+
+// cborArray encodes a DAG-CBOR array of links.
+func cborArray(t *testing.T, links ...cid.Cid) rawBlock {
+	var buf bytes.Buffer
+	require.NoError(t, cbg.WriteMajorTypeHeader(&buf, cbg.MajArray, uint64(len(links))))
+	for _, l := range links {
+		require.NoError(t, cbg.WriteCid(&buf, l))
+	}
+	return buf.Bytes()
+}
+
+func cborText(t *testing.T, s string) rawBlock {
+	var buf bytes.Buffer
+	require.NoError(t, writeCBORString(&buf, s))
+	return buf.Bytes()
+}
+
+func TestCARRoundTrip(t *testing.T) {
+	src := newMemWrapper()
+	leaf, err := src.StorePut(cborText(t, "leaf"))
+	require.NoError(t, err)
+	// identity hashed links are inlined and must not be exported.
+	inline, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.IDENTITY, MhLength: -1}.Sum([]byte("inline"))
+	require.NoError(t, err)
+	// the leaf is linked twice, and must be exported once.
+	mid, err := src.StorePut(cborArray(t, leaf, leaf))
+	require.NoError(t, err)
+	root, err := src.StorePut(cborArray(t, mid, inline, leaf))
+	require.NoError(t, err)
+
+	var car bytes.Buffer
+	require.NoError(t, ExportCAR(src, root, &car))
+
+	// the file holds the header then each block once, depth first.
+	br := bufio.NewReader(bytes.NewReader(car.Bytes()))
+	hdrRoot, err := readCARHeader(br)
+	require.NoError(t, err)
+	assert.Equal(t, root, hdrRoot)
+	var order []cid.Cid
+	for {
+		c, data, err := readCARBlock(br)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, src.blocks[c], data, "block %s", c)
+		order = append(order, c)
+	}
+	assert.Equal(t, []cid.Cid{root, mid, leaf}, order)
+
+	dst := newMemWrapper()
+	imported, err := ImportCAR(dst, bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, root, imported)
+	assert.Equal(t, src.blocks, dst.blocks)
+}
+
+func TestImportCARNative(t *testing.T) {
+	src := newMemWrapper()
+	root, err := src.StorePut(cborText(t, "root"))
+	require.NoError(t, err)
+	var car bytes.Buffer
+	require.NoError(t, ExportCAR(src, root, &car))
+
+	dst := &nativeCARWrapper{memWrapper: *newMemWrapper()}
+	_, err = ImportCAR(dst, bytes.NewReader(car.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, car.Bytes(), dst.imported)
+	assert.Empty(t, dst.blocks, "blocks were put despite the native import")
+}
+
+func TestCARHeader(t *testing.T) {
+	root, err := cborPrefix.Sum([]byte("root"))
+	require.NoError(t, err)
+	section := func(data []byte) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, writeCARSection(&buf, data))
+		return buf.Bytes()
+	}
+	other, err := cborPrefix.Sum([]byte("other"))
+	require.NoError(t, err)
+
+	// header encodes a header map with the `version` and `roots` fields, omitting nil ones, followed by `extra`.
+	header := func(roots []cid.Cid, version interface{}, extra string) []byte {
+		var hdr bytes.Buffer
+		fields := uint64(0)
+		if roots != nil {
+			fields++
+		}
+		if version != nil {
+			fields++
+		}
+		if extra != "" {
+			fields++
+		}
+		require.NoError(t, cbg.WriteMajorTypeHeader(&hdr, cbg.MajMap, fields))
+		switch v := version.(type) {
+		case uint64:
+			require.NoError(t, writeCBORString(&hdr, "version"))
+			require.NoError(t, cbg.WriteMajorTypeHeader(&hdr, cbg.MajUnsignedInt, v))
+		case string:
+			require.NoError(t, writeCBORString(&hdr, "version"))
+			require.NoError(t, writeCBORString(&hdr, v))
+		}
+		if roots != nil {
+			require.NoError(t, writeCBORString(&hdr, "roots"))
+			require.NoError(t, cbg.WriteMajorTypeHeader(&hdr, cbg.MajArray, uint64(len(roots))))
+			for _, r := range roots {
+				require.NoError(t, cbg.WriteCid(&hdr, r))
+			}
+		}
+		if extra != "" {
+			require.NoError(t, writeCBORString(&hdr, extra))
+			require.NoError(t, cbg.WriteMajorTypeHeader(&hdr, cbg.MajUnsignedInt, 0))
+		}
+		return section(hdr.Bytes())
+	}
+	var written bytes.Buffer
+	require.NoError(t, writeCARHeader(&written, root))
+
+	for _, tc := range []struct {
+		desc string
+		data []byte
+		err  bool
+	}{
+		{desc: "written header", data: written.Bytes()},
+		{desc: "version before roots", data: header([]cid.Cid{root}, uint64(1), "")},
+		{desc: "empty", data: nil, err: true},
+		{desc: "truncated", data: written.Bytes()[:written.Len()-1], err: true},
+		{desc: "not a map", data: section(cborText(t, "roots")), err: true},
+		{desc: "no roots", data: header([]cid.Cid{}, uint64(1), ""), err: true},
+		{desc: "two roots", data: header([]cid.Cid{root, other}, uint64(1), ""), err: true},
+		{desc: "missing version", data: header([]cid.Cid{root}, nil, ""), err: true},
+		{desc: "version 2", data: header([]cid.Cid{root}, uint64(2), ""), err: true},
+		{desc: "version not an integer", data: header([]cid.Cid{root}, "1", ""), err: true},
+		{desc: "unknown field", data: header([]cid.Cid{root}, uint64(1), "extra"), err: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := readCARHeader(bufio.NewReader(bytes.NewReader(tc.data)))
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, root, got)
+		})
+	}
+}
+
+func TestImportCARRejectsBlocks(t *testing.T) {
+	data := cborText(t, "block")
+	good, err := cborPrefix.Sum(data)
+	require.NoError(t, err)
+	raw, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: multihash.SHA2_256, MhLength: -1}.Sum(data)
+	require.NoError(t, err)
+	mismatched, err := cborPrefix.Sum(cborText(t, "another block"))
+	require.NoError(t, err)
+
+	block := func(c cid.Cid, data []byte) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, writeCARBlock(&buf, c, data))
+		return buf.Bytes()
+	}
+
+	for _, tc := range []struct {
+		desc   string
+		blocks []byte
+		err    bool
+	}{
+		{desc: "no blocks"},
+		{desc: "DAG-CBOR block", blocks: block(good, data)},
+		{desc: "raw block", blocks: block(raw, data), err: true},
+		{desc: "CID of other data", blocks: block(mismatched, data), err: true},
+		{desc: "truncated block", blocks: block(good, data)[:10], err: true},
+		{desc: "bad CID", blocks: []byte{3, 0xff, 0xff, 0xff}, err: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var car bytes.Buffer
+			require.NoError(t, writeCARHeader(&car, good))
+			car.Write(tc.blocks)
+
+			root, err := ImportCAR(newMemWrapper(), &car)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, good, root)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package state
+
+// ProfileSelector is implemented by validation configs that select the expectation profiles to validate against.
+type ProfileSelector interface {
+	// ExpectationProfiles returns the names of the expectation profiles to validate against, most specific first,
+	// e.g. the network version followed by the actors version. Tests fall back to the next profile when a profile
+	// has no expectations for them, and finally to the default profile.
+	ExpectationProfiles() []string
+}
+
+// ExpectationProfiles returns the expectation profiles selected by `cfg`, or none if it does not implement
+// ProfileSelector, in which case only the default profile is validated against.
+func ExpectationProfiles(cfg ValidationConfig) []string {
+	if ps, ok := cfg.(ProfileSelector); ok {
+		return ps.ExpectationProfiles()
+	}
+	return nil
+}
+
+// InvariantValidator is implemented by validation configs that enable the checks of state invariants.
+type InvariantValidator interface {
+	// ValidateInvariants checks invariants of the state after each message or tipset, such as the conservation of
+	// the total balance of all actors and the consistency of the builtin actor states.
+	ValidateInvariants() bool
+}
+
+// ValidateInvariants reports whether `cfg` enables the checks of state invariants, which it does not unless it
+// implements InvariantValidator.
+func ValidateInvariants(cfg ValidationConfig) bool {
+	if iv, ok := cfg.(InvariantValidator); ok {
+		return iv.ValidateInvariants()
+	}
+	return false
+}
+
+// PanicTolerator is implemented by validation configs that let tests continue after a panic of the implementation.
+type PanicTolerator interface {
+	// TolerateImplementationPanics lets a test continue after the implementation panics applying a message or tipset,
+	// against a fresh VM holding the state from before the message or tipset. The panic still fails the test.
+	TolerateImplementationPanics() bool
+}
+
+// TolerateImplementationPanics reports whether `cfg` lets tests continue after a panic of the implementation, which it
+// does not unless it implements PanicTolerator.
+func TolerateImplementationPanics(cfg ValidationConfig) bool {
+	if pt, ok := cfg.(PanicTolerator); ok {
+		return pt.TolerateImplementationPanics()
+	}
+	return false
+}
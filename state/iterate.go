@@ -0,0 +1,107 @@
+package state
+
+import (
+	"context"
+	"io"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/big"
+	adt "github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// ActorIterator is implemented by wrappers that enumerate the actors of their state tree natively.
+type ActorIterator interface {
+	// ForEachActor calls `f` with the ID address of each actor in the state tree, stopping at the first error.
+	ForEachActor(f func(addr address.Address, act Actor) error) error
+}
+
+// ForEachActor calls `f` with the ID address of each actor in the state tree of `vmw`, stopping at the first error.
+// Wrappers that do not implement ActorIterator have the state tree decoded from Root() through StoreGet, as a HAMT of
+// actors keyed by address.
+func ForEachActor(vmw VMWrapper, f func(addr address.Address, act Actor) error) error {
+	if it, ok := vmw.(ActorIterator); ok {
+		return it.ForEachActor(f)
+	}
+
+	tree, err := adt.AsMap(vmStore{vmw}, vmw.Root())
+	if err != nil {
+		return xerrors.Errorf("loading state tree %s: %w", vmw.Root(), err)
+	}
+	var act treeActor
+	return tree.ForEach(&act, func(k string) error {
+		addr, err := address.NewFromBytes([]byte(k))
+		if err != nil {
+			return xerrors.Errorf("decoding actor address %x: %w", k, err)
+		}
+		found := act
+		return f(addr, &found)
+	})
+}
+
+// vmStore adapts the store of a VMWrapper to the store of the actors ADTs.
+type vmStore struct {
+	vmw VMWrapper
+}
+
+func (s vmStore) Context() context.Context {
+	return context.TODO()
+}
+
+func (s vmStore) Get(_ context.Context, c cid.Cid, out interface{}) error {
+	u, ok := out.(cbg.CBORUnmarshaler)
+	if !ok {
+		return xerrors.Errorf("cannot decode %T from the store", out)
+	}
+	return s.vmw.StoreGet(c, u)
+}
+
+func (s vmStore) Put(_ context.Context, v interface{}) (cid.Cid, error) {
+	m, ok := v.(cbg.CBORMarshaler)
+	if !ok {
+		return cid.Undef, xerrors.Errorf("cannot encode %T to the store", v)
+	}
+	return s.vmw.StorePut(m)
+}
+
+// treeActor is an actor as encoded in the state tree, the tuple (Code, Head, CallSeqNum, Balance).
+type treeActor struct {
+	code       cid.Cid
+	head       cid.Cid
+	callSeqNum uint64
+	balance    big.Int
+}
+
+var _ Actor = (*treeActor)(nil)
+
+func (a *treeActor) Code() cid.Cid      { return a.code }
+func (a *treeActor) Head() cid.Cid      { return a.head }
+func (a *treeActor) CallSeqNum() uint64 { return a.callSeqNum }
+func (a *treeActor) Balance() big.Int   { return a.balance }
+
+func (a *treeActor) UnmarshalCBOR(r io.Reader) error {
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || n != 4 {
+		return xerrors.Errorf("actor is not a tuple of 4 fields")
+	}
+	if a.code, err = cbg.ReadCid(r); err != nil {
+		return xerrors.Errorf("decoding actor code: %w", err)
+	}
+	if a.head, err = cbg.ReadCid(r); err != nil {
+		return xerrors.Errorf("decoding actor head: %w", err)
+	}
+	maj, a.callSeqNum, err = cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajUnsignedInt {
+		return xerrors.Errorf("actor call sequence number is not an unsigned integer")
+	}
+	a.balance = big.Zero()
+	return a.balance.UnmarshalCBOR(r)
+}
@@ -0,0 +1,74 @@
+package state
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+)
+
+// Upgrade is a network upgrade. Messages at or after Height are executed under Network, and the state is migrated
+// to the builtin actors of version Actors when the first tipset at or after Height is applied. Upgrades keeping the
+// actors version of the state before them set Actors to that version, the default version being the zero value.
+type Upgrade struct {
+	Height  abi.ChainEpoch
+	Network network.Version
+	Actors  actors.Version
+}
+
+// UpgradeSchedule is a sequence of network upgrades ordered by height.
+type UpgradeSchedule []Upgrade
+
+// Validate returns an error unless heights and network versions both strictly increase, and actors versions do not
+// decrease.
+func (us UpgradeSchedule) Validate() error {
+	for i := 1; i < len(us); i++ {
+		if us[i].Height <= us[i-1].Height {
+			return xerrors.Errorf("upgrade to network version %d at height %d is not after the previous upgrade at height %d", us[i].Network, us[i].Height, us[i-1].Height)
+		}
+		if us[i].Network <= us[i-1].Network {
+			return xerrors.Errorf("upgrade at height %d to network version %d does not increase the network version", us[i].Height, us[i].Network)
+		}
+		if us[i].Actors < us[i-1].Actors {
+			return xerrors.Errorf("upgrade at height %d to actors version %d decreases the actors version", us[i].Height, us[i].Actors)
+		}
+	}
+	return nil
+}
+
+// NetworkVersion returns the network version at `epoch` of a chain whose genesis has network version `genesis`.
+func (us UpgradeSchedule) NetworkVersion(genesis network.Version, epoch abi.ChainEpoch) network.Version {
+	nv := genesis
+	for _, u := range us {
+		if u.Height > epoch {
+			break
+		}
+		nv = u.Network
+	}
+	return nv
+}
+
+// ActorsVersion returns the actors version of the state at `epoch` of a chain whose genesis has actors of version
+// `genesis`.
+func (us UpgradeSchedule) ActorsVersion(genesis actors.Version, epoch abi.ChainEpoch) actors.Version {
+	av := genesis
+	for _, u := range us {
+		if u.Height > epoch {
+			break
+		}
+		av = u.Actors
+	}
+	return av
+}
+
+// UpgradeApplier is implemented by appliers that migrate state at network upgrades.
+type UpgradeApplier interface {
+	VersionedApplier
+	// SetUpgradeSchedule configures the upgrades of a chain whose genesis has network version `genesis`. It returns
+	// an error wrapping ErrUnsupportedNetworkVersion if the applier cannot perform any of the upgrades.
+	// While a schedule is set the applier migrates the state before applying the first tipset at or after the height
+	// of each upgrade, to the actors version of the upgrade.
+	SetUpgradeSchedule(genesis network.Version, schedule UpgradeSchedule) error
+}
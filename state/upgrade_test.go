@@ -0,0 +1,25 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/chain-validation/chain/actors"
+)
+
+func TestUpgradeScheduleActorsVersion(t *testing.T) {
+	us := UpgradeSchedule{
+		{Height: 2, Network: network.Version1, Actors: actors.Version0},
+		{Height: 4, Network: network.Version2, Actors: actors.Version0 + 1},
+	}
+	assert.NoError(t, us.Validate())
+	for epoch, expected := range map[abi.ChainEpoch]actors.Version{1: 0, 2: 0, 3: 0, 4: 1, 5: 1} {
+		assert.Equal(t, expected, us.ActorsVersion(actors.Version0, epoch), "epoch %d", epoch)
+	}
+
+	decreasing := UpgradeSchedule{us[1], {Height: 6, Network: network.Version3, Actors: actors.Version0}}
+	assert.Error(t, decreasing.Validate())
+}
@@ -61,4 +61,5 @@ type ValidationConfig interface {
 	ValidateExitCode() bool
 	ValidateReturnValue() bool
 	ValidateStateRoot() bool
+	ValidateRewardsAndPenalties() bool
 }
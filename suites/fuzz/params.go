@@ -0,0 +1,132 @@
+package fuzz
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	crypto_spec "github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	paych_spec "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	"github.com/filecoin-project/specs-actors/actors/puppet"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+const mutationsPerMethod = 16
+
+// execReturn applies `msg`, which must create an actor through the init actor, returning the ID address of the actor.
+func execReturn(dd *drivers.DifferentialDriver, msg *types.Message) address.Address {
+	result, _ := dd.ApplyMessage(msg)
+	require.Equal(dd.T, exitcode.Ok, result.Receipt.ExitCode)
+	var ret init_spec.ExecReturn
+	require.NoError(dd.T, ret.UnmarshalCBOR(bytes.NewReader(result.Receipt.ReturnValue)))
+	return ret.IDAddress
+}
+
+// Sends messages whose params were mutated into malformed, truncated, over-long or non-canonical CBOR to the methods of
+// the builtin actors. Implementations must not panic, must charge the same gas for the same message, and must fail
+// malformed params with ErrSerialization.
+func FuzzTest_MessageParams(t *testing.T, factory state.Factories) {
+	testCases := []struct {
+		desc    string
+		produce func(dd *drivers.DifferentialDriver) *types.Message
+	}{
+		{
+			"init exec",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				alice, _ := dd.NewAccountActor(drivers.SECP, accountBalance)
+				bob, _ := dd.NewAccountActor(drivers.SECP, big.Zero())
+				return dd.MessageProducer.CreatePaymentChannelActor(alice, bob, chain.Nonce(0))
+			},
+		},
+		{
+			"multisig propose",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				_, alice := dd.NewAccountActor(drivers.SECP, accountBalance)
+				ms := execReturn(dd, dd.MessageProducer.CreateMultisigActor(alice, []address.Address{alice}, 0, 1, chain.Value(big.NewInt(1_000)), chain.Nonce(0)))
+				return dd.MessageProducer.MultisigPropose(alice, ms, &multisig_spec.ProposeParams{
+					To:     alice,
+					Value:  big.NewInt(1),
+					Method: builtin_spec.MethodSend,
+				}, chain.Nonce(1))
+			},
+		},
+		{
+			"multisig approve",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				_, alice := dd.NewAccountActor(drivers.SECP, accountBalance)
+				ms := execReturn(dd, dd.MessageProducer.CreateMultisigActor(alice, []address.Address{alice}, 0, 1, chain.Nonce(0)))
+				return dd.MessageProducer.MultisigApprove(alice, ms, &multisig_spec.TxnIDParams{ID: 0}, chain.Nonce(1))
+			},
+		},
+		{
+			"paych update channel state",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				alice, _ := dd.NewAccountActor(drivers.SECP, accountBalance)
+				bob, _ := dd.NewAccountActor(drivers.SECP, accountBalance)
+				pc := execReturn(dd, dd.MessageProducer.CreatePaymentChannelActor(alice, bob, chain.Value(big.NewInt(1_000)), chain.Nonce(0)))
+				return dd.MessageProducer.PaychUpdateChannelState(alice, pc, &paych_spec.UpdateChannelStateParams{
+					Sv: paych_spec.SignedVoucher{
+						ChannelAddr: pc,
+						Lane:        1,
+						Nonce:       1,
+						Amount:      big.NewInt(10),
+						Signature: &crypto_spec.Signature{
+							Type: crypto_spec.SigTypeSecp256k1,
+							Data: []byte("signature goes here"),
+						},
+					},
+				}, chain.Nonce(1))
+			},
+		},
+		{
+			"power create miner",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				alice, _ := dd.NewAccountActor(drivers.SECP, accountBalance)
+				worker, _ := dd.NewAccountActor(drivers.BLS, big.Zero())
+				return dd.MessageProducer.CreateMinerActor(alice, worker, abi.RegisteredSealProof_StackedDrg2KiBV1, "peer", nil, chain.Nonce(0))
+			},
+		},
+		{
+			"market add balance",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				alice, _ := dd.NewAccountActor(drivers.SECP, accountBalance)
+				return dd.MessageProducer.MarketAddBalance(alice, builtin_spec.StorageMarketActorAddr, &alice, chain.Value(big.NewInt(1_000)), chain.Nonce(0))
+			},
+		},
+		{
+			"puppet send",
+			func(dd *drivers.DifferentialDriver) *types.Message {
+				alice, _ := dd.NewAccountActor(drivers.SECP, accountBalance)
+				return dd.MessageProducer.PuppetSend(alice, drivers.PuppetAddress, &puppet.SendParams{
+					To:     alice,
+					Value:  big.Zero(),
+					Method: builtin_spec.MethodSend,
+				}, chain.Nonce(0))
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc
+		seeds := sequenceSeeds(t)
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, seed := range seeds {
+				t.Run(fmt.Sprintf("seed%d", seed), func(t *testing.T) {
+					drivers.FuzzMessageParams(t, factory, seed*int64(len(testCases))+int64(i), mutationsPerMethod, configureBuilder, tc.produce)
+				})
+			}
+		})
+	}
+}
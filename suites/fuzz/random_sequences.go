@@ -0,0 +1,122 @@
+package fuzz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+const (
+	// SeedEnvVar selects the seed of the first sequence generated, e.g. to reproduce a failure.
+	SeedEnvVar = "CHAIN_VALIDATION_FUZZ_SEED"
+	// SequencesEnvVar sets the number of sequences generated, each with the seed following that of the previous one.
+	// Set it high for long running fuzzing.
+	SequencesEnvVar = "CHAIN_VALIDATION_FUZZ_SEQUENCES"
+)
+
+const (
+	defaultSequences = 4
+	sequenceLength   = 100
+	accountCount     = 6
+)
+
+var accountBalance = abi.NewTokenAmount(1_000_000_000_000_000)
+
+// sequenceSeeds returns the seeds of the sequences to generate.
+func sequenceSeeds(t *testing.T) []int64 {
+	first, count := int64(1), defaultSequences
+	if env := os.Getenv(SeedEnvVar); env != "" {
+		seed, err := strconv.ParseInt(env, 10, 64)
+		require.NoError(t, err, "parsing %s", SeedEnvVar)
+		first, count = seed, 1
+	}
+	if env := os.Getenv(SequencesEnvVar); env != "" {
+		n, err := strconv.Atoi(env)
+		require.NoError(t, err, "parsing %s", SequencesEnvVar)
+		count = n
+	}
+	seeds := make([]int64, count)
+	for i := range seeds {
+		seeds[i] = first + int64(i)
+	}
+	return seeds
+}
+
+func configureBuilder(b *drivers.TestDriverBuilder) {
+	b.WithDefaultGasLimit(1_000_000_000).
+		WithDefaultGasFeeCap(200).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState().
+		WithPuppetActor()
+}
+
+func generatorConfig() drivers.GeneratorConfig {
+	cfg := drivers.DefaultGeneratorConfig
+	cfg.Puppet = drivers.PuppetAddress
+	return cfg
+}
+
+// Applies random sequences of messages between a few accounts, checking the invariants enabled by the validation
+// config after each message. Sequences are not validated against recorded expectations.
+func FuzzTest_RandomSequences(t *testing.T, factory state.Factories) {
+	for _, seed := range sequenceSeeds(t) {
+		seed := seed
+		t.Run(fmt.Sprintf("seed%d", seed), func(t *testing.T) {
+			b := drivers.NewBuilder(context.Background(), factory)
+			configureBuilder(b)
+			td := b.Build(t)
+			defer td.Complete()
+
+			accounts := td.NewGeneratorAccounts(accountCount, accountBalance)
+			gen := drivers.NewMessageGenerator(seed, generatorConfig(), td.MessageProducer, accounts, td.ExeCtx.Epoch)
+			td.ApplyGenerated(gen, sequenceLength)
+		})
+	}
+}
+
+// DifferentialTest_RandomSequences applies random sequences of messages between a few accounts to the implementations
+// of two factories, comparing their results after each message.
+func DifferentialTest_RandomSequences(t *testing.T, factoryA, factoryB state.Factories) {
+	for _, seed := range sequenceSeeds(t) {
+		seed := seed
+		t.Run(fmt.Sprintf("seed%d", seed), func(t *testing.T) {
+			dd := drivers.NewDifferentialDriver(t, factoryA, factoryB, configureBuilder)
+
+			accounts := make([]drivers.GeneratorAccount, accountCount)
+			for i := range accounts {
+				protocol := drivers.SECP
+				if i%2 == 1 {
+					protocol = drivers.BLS
+				}
+				accounts[i].Pubkey, accounts[i].ID = dd.NewAccountActor(protocol, accountBalance)
+			}
+			gen := drivers.NewMessageGenerator(seed, generatorConfig(), dd.MessageProducer, accounts, dd.A.ExeCtx.Epoch)
+			dd.StartRecording()
+			dd.ApplyGenerated(gen, sequenceLength)
+			if t.Failed() {
+				logMinimalSequence(t, dd.Recording(), factoryA, factoryB)
+			}
+		})
+	}
+}
+
+// logMinimalSequence logs a minimal subsequence of the recorded messages that still diverges, to ease triage.
+func logMinimalSequence(t *testing.T, rec *drivers.Recording, factoryA, factoryB state.Factories) {
+	steps, err := drivers.Minimize(t, rec, factoryA, factoryB, configureBuilder)
+	if err != nil {
+		t.Logf("not minimising the diverging sequence: %s", err)
+		return
+	}
+	t.Logf("minimal diverging sequence of %d messages:", len(steps))
+	for i, step := range steps {
+		t.Logf("\t%d: %s", i, step)
+	}
+}
@@ -0,0 +1,408 @@
+package message
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-bitfield"
+	commcid "github.com/filecoin-project/go-fil-commcid"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	crypto_spec "github.com/filecoin-project/go-state-types/crypto"
+	exitcode_spec "github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/account"
+	"github.com/filecoin-project/specs-actors/actors/builtin/cron"
+	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/actors/builtin/market"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	paych_spec "github.com/filecoin-project/specs-actors/actors/builtin/paych"
+	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/actors/builtin/reward"
+	"github.com/filecoin-project/specs-actors/actors/builtin/system"
+	"github.com/filecoin-project/specs-actors/actors/builtin/verifreg"
+	"github.com/filecoin-project/specs-actors/actors/puppet"
+	cid "github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+	"github.com/filecoin-project/chain-validation/suites/utils"
+)
+
+// callerClass is a class of callers a method may reject, as a set of flags.
+type callerClass uint
+
+const (
+	// an account without any role
+	randomAccount callerClass = 1 << iota
+	// the owner of a miner other than the one called
+	otherMinerOwner
+	// the worker of a miner other than the one called
+	otherMinerWorker
+	// an actor that is neither an account nor a system actor, calling through the puppet actor
+	nonSystemActor
+
+	accountCallers = randomAccount | otherMinerOwner | otherMinerWorker
+	everyCaller    = accountCallers | nonSystemActor
+)
+
+// rejection is a class of callers a method rejects and the exit code it rejects them with.
+type rejection struct {
+	callers callerClass
+	code    exitcode_spec.ExitCode
+}
+
+var (
+	// methods restricted to system actors, or to specific actors none of the callers is.
+	forbiddenToAll = []rejection{{everyCaller, exitcode_spec.SysErrForbidden}}
+	// methods restricted to accounts and multisigs.
+	signableOnly = []rejection{{nonSystemActor, exitcode_spec.SysErrForbidden}}
+	// methods restricted to the signers of a multisig.
+	signersOnly = []rejection{{accountCallers, exitcode_spec.ErrForbidden}, {nonSystemActor, exitcode_spec.SysErrForbidden}}
+	// methods restricted to verifiers, which none of the callers is.
+	verifiersOnly = []rejection{{everyCaller, exitcode_spec.ErrNotFound}}
+	// methods any caller may call.
+	acceptsAnyCaller []rejection
+)
+
+// callerAuthorizations are the rejections of each exported method of the v0 builtin actors, keyed by actor and
+// method name. Every exported method must be listed.
+var callerAuthorizations = map[string]map[string][]rejection{
+	"account": {
+		"Constructor":   forbiddenToAll,
+		"PubkeyAddress": acceptsAnyCaller,
+	},
+	"system": {
+		"Constructor": forbiddenToAll,
+	},
+	"init": {
+		"Constructor": forbiddenToAll,
+		"Exec":        acceptsAnyCaller,
+	},
+	"cron": {
+		"Constructor": forbiddenToAll,
+		"EpochTick":   forbiddenToAll,
+	},
+	"reward": {
+		"Constructor":      forbiddenToAll,
+		"AwardBlockReward": forbiddenToAll,
+		"ThisEpochReward":  acceptsAnyCaller,
+		"UpdateNetworkKPI": forbiddenToAll,
+	},
+	"power": {
+		"Constructor":              forbiddenToAll,
+		"CreateMiner":              signableOnly,
+		"UpdateClaimedPower":       forbiddenToAll,
+		"EnrollCronEvent":          forbiddenToAll,
+		"OnEpochTickEnd":           forbiddenToAll,
+		"UpdatePledgeTotal":        forbiddenToAll,
+		"OnConsensusFault":         forbiddenToAll,
+		"SubmitPoRepForBulkVerify": forbiddenToAll,
+		"CurrentTotalPower":        acceptsAnyCaller,
+	},
+	"market": {
+		"Constructor": forbiddenToAll,
+		"AddBalance":  signableOnly,
+		// the balance withdrawn is that of an account none of the callers is.
+		"WithdrawBalance":          forbiddenToAll,
+		"PublishStorageDeals":      signableOnly,
+		"VerifyDealsForActivation": forbiddenToAll,
+		"ActivateDeals":            forbiddenToAll,
+		"OnMinerSectorsTerminate":  forbiddenToAll,
+		"ComputeDataCommitment":    forbiddenToAll,
+		"CronTick":                 forbiddenToAll,
+	},
+	"miner": {
+		"Constructor":              forbiddenToAll,
+		"ControlAddresses":         acceptsAnyCaller,
+		"ChangeWorkerAddress":      forbiddenToAll,
+		"ChangePeerID":             forbiddenToAll,
+		"SubmitWindowedPoSt":       forbiddenToAll,
+		"PreCommitSector":          forbiddenToAll,
+		"ProveCommitSector":        acceptsAnyCaller,
+		"ExtendSectorExpiration":   forbiddenToAll,
+		"TerminateSectors":         forbiddenToAll,
+		"DeclareFaults":            forbiddenToAll,
+		"DeclareFaultsRecovered":   forbiddenToAll,
+		"OnDeferredCronEvent":      forbiddenToAll,
+		"CheckSectorProven":        acceptsAnyCaller,
+		"AddLockedFund":            forbiddenToAll,
+		"ReportConsensusFault":     signableOnly,
+		"WithdrawBalance":          forbiddenToAll,
+		"ConfirmSectorProofsValid": forbiddenToAll,
+		"ChangeMultiaddrs":         forbiddenToAll,
+		"CompactPartitions":        forbiddenToAll,
+		"CompactSectorNumbers":     forbiddenToAll,
+	},
+	"multisig": {
+		"Constructor":                 forbiddenToAll,
+		"Propose":                     signersOnly,
+		"Approve":                     signersOnly,
+		"Cancel":                      signersOnly,
+		"AddSigner":                   forbiddenToAll,
+		"RemoveSigner":                forbiddenToAll,
+		"SwapSigner":                  forbiddenToAll,
+		"ChangeNumApprovalsThreshold": forbiddenToAll,
+	},
+	"paych": {
+		"Constructor":        forbiddenToAll,
+		"UpdateChannelState": forbiddenToAll,
+		"Settle":             forbiddenToAll,
+		"Collect":            forbiddenToAll,
+	},
+	"verifreg": {
+		"Constructor": forbiddenToAll,
+		// the root key is none of the callers.
+		"AddVerifier":       forbiddenToAll,
+		"RemoveVerifier":    forbiddenToAll,
+		"AddVerifiedClient": verifiersOnly,
+		"UseBytes":          forbiddenToAll,
+		"RestoreBytes":      forbiddenToAll,
+	},
+}
+
+// validParams builds the params of methods checking them before their caller, keyed by actor and method name, so
+// that the caller is checked. Other methods are called with well-formed but arbitrary params, see fillParams.
+var validParams = map[string]func(s *authStage) cbg.CBORMarshaler{
+	"miner.PreCommitSector": func(s *authStage) cbg.CBORMarshaler {
+		epoch := s.td.ExeCtx.Epoch
+		sealed, err := commcid.ReplicaCommitmentV1ToCID(make([]byte, 32))
+		require.NoError(s.td.T, err)
+		return &miner.SectorPreCommitInfo{
+			SealProof:     drivers.TestSealProofType,
+			SectorNumber:  1,
+			SealedCID:     sealed,
+			SealRandEpoch: epoch - 1,
+			// the sector outlives the minimum lifetime even if activated as late as its seal proof allows.
+			Expiration: epoch + miner.MaxSealDuration[drivers.TestSealProofType] + miner.MinSectorExpiration + builtin_spec.EpochsInDay,
+		}
+	},
+	"verifreg.AddVerifiedClient": func(s *authStage) cbg.CBORMarshaler {
+		return &verifreg.AddVerifiedClientParams{Address: s.account, Allowance: verifreg.MinVerifiedDealSize}
+	},
+}
+
+// verifiedRegistryRootKey administers the verified registry, an ID below those of non-singleton actors so that none
+// of the callers holds it.
+var verifiedRegistryRootKey = func() address.Address {
+	addr, err := address.NewIDAddress(80)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}()
+
+// Calls every exported method of every builtin actor in the state from each class of caller the method must reject,
+// with well-formed params, valid ones for methods checking them first, expecting the caller to be rejected without
+// any change of state. The calls are generated from the exports of the v0 actors, checked against the authorizations
+// listed for them.
+func MessageTest_CallerAuthorization(t *testing.T, factory state.Factories) {
+	builder := drivers.NewBuilder(context.Background(), factory).
+		WithDefaultGasLimit(1_000_000_000).
+		WithDefaultGasFeeCap(200).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState().
+		WithVerifiedRegistry(verifiedRegistryRootKey).
+		WithPuppetActor()
+
+	for _, target := range []struct {
+		name     string
+		exports  []interface{}
+		receiver func(*authStage) address.Address
+	}{
+		{"account", account.Actor{}.Exports(), func(s *authStage) address.Address { return s.account }},
+		{"system", system.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.SystemActorAddr }},
+		{"init", init_spec.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.InitActorAddr }},
+		{"cron", cron.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.CronActorAddr }},
+		{"reward", reward.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.RewardActorAddr }},
+		{"power", power_spec.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.StoragePowerActorAddr }},
+		{"market", market.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.StorageMarketActorAddr }},
+		{"miner", miner.Actor{}.Exports(), func(s *authStage) address.Address { return s.miner }},
+		{"multisig", multisig.Actor{}.Exports(), func(s *authStage) address.Address { return s.multisig }},
+		{"paych", paych_spec.Actor{}.Exports(), func(s *authStage) address.Address { return s.paych }},
+		{"verifreg", verifreg.Actor{}.Exports(), func(*authStage) address.Address { return builtin_spec.VerifiedRegistryActorAddr }},
+	} {
+		target := target
+		t.Run(target.name, func(t *testing.T) {
+			td := builder.Build(t)
+			defer td.Complete()
+			if td.Actors().Version() != actors.Version0 {
+				t.Skip("caller authorizations are listed for the v0 actors")
+			}
+			rules, ok := callerAuthorizations[target.name]
+			require.True(t, ok, "no caller authorizations listed for the %s actor", target.name)
+
+			stage := newAuthStage(td)
+			receiver := target.receiver(stage)
+			exported := make(map[string]bool)
+			for num, export := range target.exports {
+				if export == nil {
+					continue
+				}
+				name := actors.ExportedMethodName(export)
+				exported[name] = true
+				rejections, ok := rules[name]
+				if !assert.True(t, ok, "no caller authorization listed for %s.%s", target.name, name) {
+					continue
+				}
+				var params []byte
+				if build, ok := validParams[target.name+"."+name]; ok {
+					params = chain.MustSerialize(build(stage))
+				} else {
+					params = stage.params(reflect.TypeOf(export).In(1))
+				}
+				for _, rej := range rejections {
+					for _, c := range stage.callers {
+						if rej.callers&c.class != 0 {
+							stage.call(c, receiver, abi_spec.MethodNum(num), params, rej.code, target.name+"."+name)
+						}
+					}
+				}
+			}
+			for name := range rules {
+				assert.True(t, exported[name], "caller authorization listed for %s.%s, which is not exported", target.name, name)
+			}
+		})
+	}
+}
+
+// authCaller is a caller of a class, sending from an account directly or through the puppet actor.
+type authCaller struct {
+	class callerClass
+	desc  string
+	from  address.Address
+	// fromId is the ID address of `from`.
+	fromId address.Address
+	// viaPuppet relays the call through the puppet actor.
+	viaPuppet bool
+	nonce     uint64
+}
+
+// authStage holds an instance of each non-singleton builtin actor, none of them controlled by the callers.
+type authStage struct {
+	td      *drivers.TestDriver
+	callers []*authCaller
+
+	account  address.Address
+	miner    address.Address
+	multisig address.Address
+	paych    address.Address
+}
+
+func newAuthStage(td *drivers.TestDriver) *authStage {
+	var initialBal = abi_spec.NewTokenAmount(1_000_000_000_000)
+	var value = abi_spec.NewTokenAmount(10_000)
+	s := &authStage{td: td}
+
+	creator, creatorId := td.NewAccountActor(drivers.SECP, initialBal)
+	creatorWorker, _ := td.NewAccountActor(drivers.BLS, big_spec.Zero())
+	_, s.account = td.NewAccountActor(drivers.SECP, initialBal)
+
+	s.miner = s.createMiner(creator, creatorWorker, 0)
+	s.multisig = utils.NewIDAddr(td.T, uint64(td.InitNextID()))
+	msRet := td.ComputeInitActorExecReturn(creator, 1, 0, s.multisig)
+	td.ApplyExpectReturn(
+		td.MessageProducer.CreateMultisigActor(creator, []address.Address{creatorId}, 0, 1, chain.Value(value), chain.Nonce(1)),
+		&msRet)
+	s.paych = utils.NewIDAddr(td.T, uint64(td.InitNextID()))
+	paychRet := td.ComputeInitActorExecReturn(creator, 2, 0, s.paych)
+	td.ApplyExpectReturn(
+		td.MessageProducer.CreatePaymentChannelActor(creator, s.account, chain.Value(value), chain.Nonce(2)),
+		&paychRet)
+
+	random, randomId := td.NewAccountActor(drivers.SECP, initialBal)
+	owner, ownerId := td.NewAccountActor(drivers.SECP, initialBal)
+	worker, workerId := td.NewAccountActor(drivers.BLS, initialBal)
+	puppeteer, puppeteerId := td.NewAccountActor(drivers.SECP, initialBal)
+	s.createMiner(owner, worker, 0)
+	s.callers = []*authCaller{
+		{class: randomAccount, desc: "random account", from: random, fromId: randomId},
+		{class: otherMinerOwner, desc: "other miner owner", from: owner, fromId: ownerId, nonce: 1},
+		{class: otherMinerWorker, desc: "other miner worker", from: worker, fromId: workerId},
+		{class: nonSystemActor, desc: "non-system actor", from: puppeteer, fromId: puppeteerId, viaPuppet: true},
+	}
+	return s
+}
+
+// createMiner creates a miner owned by `owner`, sending from it with `nonce`.
+func (s *authStage) createMiner(owner, worker address.Address, nonce uint64) address.Address {
+	minerAddr := utils.NewIDAddr(s.td.T, uint64(s.td.InitNextID()))
+	ret := s.td.ComputeInitActorExecReturn(owner, nonce, 0, minerAddr)
+	s.td.ApplyExpectReturn(
+		s.td.MessageProducer.CreateMinerActor(owner, worker, drivers.TestSealProofType, "peer", nil, chain.Nonce(nonce)),
+		&power_spec.CreateMinerReturn{
+			IDAddress:     ret.IDAddress,
+			RobustAddress: ret.RobustAddress,
+		})
+	return minerAddr
+}
+
+// params returns serialized well-formed params of type `typ`, a pointer type.
+func (s *authStage) params(typ reflect.Type) []byte {
+	v := reflect.New(typ.Elem())
+	fillParams(v.Elem(), s.account, s.td.Actors().Codes().Account)
+	m, ok := v.Interface().(cbg.CBORMarshaler)
+	require.True(s.td.T, ok, "params %s do not marshal to CBOR", typ)
+	return chain.MustSerialize(m)
+}
+
+// fillParams sets the addresses, CIDs, bitfields and signatures in `v` to well-formed values, so that params
+// serialize and deserialize. Other values are left zero.
+func fillParams(v reflect.Value, addr address.Address, c cid.Cid) {
+	switch v.Type() {
+	case reflect.TypeOf(address.Address{}):
+		v.Set(reflect.ValueOf(addr))
+	case reflect.TypeOf(cid.Cid{}):
+		v.Set(reflect.ValueOf(c))
+	case reflect.TypeOf(bitfield.BitField{}):
+		v.Set(reflect.ValueOf(bitfield.NewFromSet([]uint64{0})))
+	case reflect.TypeOf(crypto_spec.Signature{}):
+		v.Set(reflect.ValueOf(crypto_spec.Signature{Type: crypto_spec.SigTypeSecp256k1}))
+	default:
+		if v.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				fillParams(v.Field(i), addr, c)
+			}
+		}
+	}
+}
+
+// call calls `method` of `receiver` from `c`, expecting it to be rejected with `code` and the state of every actor
+// but the sender's and those collecting gas to be unchanged.
+func (s *authStage) call(c *authCaller, receiver address.Address, method abi_spec.MethodNum, params []byte, code exitcode_spec.ExitCode, what string) {
+	td := s.td
+	before := td.SnapshotAllActors()
+	prevHead := td.GetHead(receiver)
+
+	if c.viaPuppet {
+		result := td.ApplyMessage(td.MessageProducer.PuppetSend(c.from, drivers.PuppetAddress, &puppet.SendParams{
+			To:     receiver,
+			Value:  big_spec.Zero(),
+			Method: method,
+			Params: params,
+		}, chain.Nonce(c.nonce)))
+		if assert.Equal(td.T, exitcode_spec.Ok, result.Receipt.ExitCode, "%s from %s: puppet send failed", what, c.desc) {
+			var ret puppet.SendReturn
+			chain.MustDeserialize(result.Receipt.ReturnValue, &ret)
+			assert.Equal(td.T, code, ret.Code, "%s from %s: Expected ExitCode: %s Actual ExitCode: %s", what, c.desc, code, ret.Code)
+		}
+	} else {
+		result := td.ApplyMessage(td.MessageProducer.Build(c.from, receiver, method, params, chain.Nonce(c.nonce), chain.Value(big_spec.Zero())))
+		assert.Equal(td.T, code, result.Receipt.ExitCode, "%s from %s: Expected ExitCode: %s Actual ExitCode: %s", what, c.desc, code, result.Receipt.ExitCode)
+	}
+	c.nonce++
+
+	td.AssertHead(receiver, prevHead)
+	td.AssertOnlyActorsChanged(before, c.fromId, builtin_spec.BurntFundsActorAddr, builtin_spec.RewardActorAddr)
+}
@@ -20,7 +20,7 @@ func MessageTest_AccountActorCreation(t *testing.T, factory state.Factories) {
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	testCases := []struct {
 		desc string
@@ -81,12 +81,16 @@ func MessageTest_AccountActorCreation(t *testing.T, factory state.Factories) {
 			defer td.Complete()
 
 			existingAccountAddr, _ := td.NewAccountActor(tc.existingActorType, tc.existingActorBal)
+			actorCount := len(td.SnapshotAllActors())
 			result := td.ApplyFailure(td.MessageProducer.Transfer(existingAccountAddr, tc.newActorAddr, chain.Value(tc.newActorInitBal), chain.Nonce(0)), tc.expExitCode)
 
 			// new actor balance will only exist if message was applied successfully.
 			if tc.expExitCode.IsSuccess() {
+				td.AssertActorCount(actorCount + 1)
 				td.AssertBalance(tc.newActorAddr, tc.newActorInitBal)
 				td.AssertActorChange(existingAccountAddr, tc.existingActorBal, result.Msg.GasLimit, result.Msg.GasPremium, tc.newActorInitBal, result.Receipt, 1)
+			} else {
+				td.AssertActorCount(actorCount)
 			}
 		})
 	}
@@ -97,7 +101,7 @@ func MessageTest_InitActorSequentialIDAddressCreate(t *testing.T, factory state.
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...).Build(t)
+		WithDefaultBuiltinActorsState().Build(t)
 	defer td.Complete()
 
 	var initialBal = abi_spec.NewTokenAmount(1_000_000_000_000)
@@ -113,13 +117,13 @@ func MessageTest_InitActorSequentialIDAddressCreate(t *testing.T, factory state.
 	firstInitRet := td.ComputeInitActorExecReturn(sender, 0, 0, firstPaychAddr)
 	secondInitRet := td.ComputeInitActorExecReturn(sender, 1, 0, secondPaychAddr)
 
-	td.ApplyExpect(
+	td.ApplyExpectReturn(
 		td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(0)),
-		chain.MustSerialize(&firstInitRet),
+		&firstInitRet,
 	)
 
-	td.ApplyExpect(
+	td.ApplyExpectReturn(
 		td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(1)),
-		chain.MustSerialize(&secondInitRet),
+		&secondInitRet,
 	)
 }
@@ -0,0 +1,138 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	exitcode_spec "github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	init_spec "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	cid "github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+	"github.com/filecoin-project/chain-validation/suites/utils"
+)
+
+// Execs actors through the init actor, checking which code may be exec'd and the addresses it assigns.
+func MessageTest_InitActorExec(t *testing.T, factory state.Factories) {
+	builder := drivers.NewBuilder(context.Background(), factory).
+		WithDefaultGasLimit(1_000_000_000).
+		WithDefaultGasFeeCap(200).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState()
+
+	var initialBal = abi_spec.NewTokenAmount(1_000_000_000_000)
+	var toSend = abi_spec.NewTokenAmount(10_000)
+
+	t.Run("exec forbidden code", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		sender, _ := td.NewAccountActor(drivers.SECP, initialBal)
+		codes := td.Actors().Codes()
+
+		// only payment channels and multisigs may be exec'd by accounts, miners are exec'd by the power actor.
+		forbidden := []struct {
+			desc string
+			code cid.Cid
+		}{
+			{"account", codes.Account},
+			{"miner", codes.Miner},
+			{"market", codes.Market},
+			{"power", codes.Power},
+			{"reward", codes.Reward},
+			{"cron", codes.Cron},
+			{"system", codes.System},
+			{"init", codes.Init},
+			{"verified registry", codes.VerifiedReg},
+		}
+
+		nextID := td.InitNextID()
+		actorCount := len(td.SnapshotAllActors())
+		for i, tc := range forbidden {
+			td.ApplyFailure(
+				td.MessageProducer.InitExec(sender, builtin_spec.InitActorAddr, &init_spec.ExecParams{CodeCID: tc.code},
+					chain.Value(big_spec.Zero()), chain.Nonce(uint64(i))),
+				exitcode_spec.ErrForbidden)
+			assert.Equal(t, nextID, td.InitNextID(), "exec of %s code assigned an ID", tc.desc)
+		}
+		td.AssertActorCount(actorCount)
+	})
+
+	t.Run("address mapping", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		sender, senderId := td.NewAccountActor(drivers.SECP, initialBal)
+		receiver, receiverId := td.NewAccountActor(drivers.SECP, initialBal)
+		worker, _ := td.NewAccountActor(drivers.BLS, big_spec.Zero())
+
+		// the robust address of an exec'd actor is derived from the origin of the message and its nonce.
+		nextID := td.InitNextID()
+		paychRet := td.ComputeInitActorExecReturn(sender, 0, 0, utils.NewIDAddr(t, uint64(nextID)))
+		td.ApplyExpectReturn(
+			td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(0)),
+			&paychRet)
+		td.AssertInitAddressMapping(paychRet.RobustAddress, paychRet.IDAddress)
+
+		msRet := td.ComputeInitActorExecReturn(receiver, 0, 0, utils.NewIDAddr(t, uint64(nextID)+1))
+		td.ApplyExpectReturn(
+			td.MessageProducer.CreateMultisigActor(receiver, []address.Address{senderId, receiverId}, 0, 1, chain.Value(toSend), chain.Nonce(0)),
+			&msRet)
+		td.AssertInitAddressMapping(msRet.RobustAddress, msRet.IDAddress)
+
+		// miners are exec'd by the power actor on behalf of the origin of the message.
+		minerRet := td.ComputeInitActorExecReturn(sender, 1, 0, utils.NewIDAddr(t, uint64(nextID)+2))
+		td.ApplyExpectReturn(
+			td.MessageProducer.CreateMinerActor(sender, worker, abi_spec.RegisteredSealProof_StackedDrg2KiBV1, "peer", nil, chain.Nonce(1)),
+			&power_spec.CreateMinerReturn{
+				IDAddress:     minerRet.IDAddress,
+				RobustAddress: minerRet.RobustAddress,
+			})
+		td.AssertInitAddressMapping(minerRet.RobustAddress, minerRet.IDAddress)
+
+		// accounts created by a transfer map their key address.
+		newAccount := utils.NewSECP256K1Addr(t, "initexec")
+		td.ApplyOk(td.MessageProducer.Transfer(sender, newAccount, chain.Value(toSend), chain.Nonce(2)))
+		td.AssertInitAddressMapping(newAccount, utils.NewIDAddr(t, uint64(nextID)+3))
+		assert.Equal(t, nextID+4, td.InitNextID())
+	})
+
+	t.Run("next id under failures", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		sender, senderId := td.NewAccountActor(drivers.SECP, initialBal)
+		receiver, _ := td.NewAccountActor(drivers.SECP, initialBal)
+		nextID := td.InitNextID()
+
+		// a forbidden exec does not assign an ID.
+		td.ApplyFailure(
+			td.MessageProducer.InitExec(sender, builtin_spec.InitActorAddr, &init_spec.ExecParams{CodeCID: td.Actors().Codes().Account},
+				chain.Value(big_spec.Zero()), chain.Nonce(0)),
+			exitcode_spec.ErrForbidden)
+		assert.Equal(t, nextID, td.InitNextID())
+
+		// nor does an exec whose constructor fails, the ID is reverted with the rest of the state.
+		td.ApplyFailure(
+			td.MessageProducer.CreateMultisigActor(sender, []address.Address{senderId}, 0, 2, chain.Value(toSend), chain.Nonce(1)),
+			exitcode_spec.ErrIllegalArgument)
+		assert.Equal(t, nextID, td.InitNextID())
+		td.AssertNoActor(utils.NewIDAddr(t, uint64(nextID)))
+
+		// the next exec is assigned the ID none of the failures took, under a robust address of its own nonce.
+		paychRet := td.ComputeInitActorExecReturn(sender, 2, 0, utils.NewIDAddr(t, uint64(nextID)))
+		td.ApplyExpectReturn(
+			td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(2)),
+			&paychRet)
+		td.AssertInitAddressMapping(paychRet.RobustAddress, paychRet.IDAddress)
+		assert.Equal(t, nextID+1, td.InitNextID())
+	})
+}
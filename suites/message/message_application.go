@@ -22,7 +22,7 @@ func MessageTest_MessageApplicationEdgecases(t *testing.T, factory state.Factori
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	var aliceBal = abi_spec.NewTokenAmount(1_000_000_000_000)
 	var transferAmnt = abi_spec.NewTokenAmount(10)
@@ -123,9 +123,9 @@ func MessageTest_MessageApplicationEdgecases(t *testing.T, factory state.Factori
 		// the _expected_ address of the payment channel
 		paychAddr := utils.NewIDAddr(t, utils.IdFromAddress(receiverID)+1)
 		createRet := td.ComputeInitActorExecReturn(sender, 0, 0, paychAddr)
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(0)),
-			chain.MustSerialize(&createRet))
+			&createRet)
 
 		// message application fails due to invalid argument (signature).
 		td.ApplyFailure(
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
 	"github.com/filecoin-project/chain-validation/drivers"
 	"github.com/filecoin-project/chain-validation/state"
 	"github.com/filecoin-project/chain-validation/suites/utils"
@@ -23,7 +24,7 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	t.Run("constructor test", func(t *testing.T) {
 		const numApprovals = 1
@@ -93,9 +94,9 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 			Code:    0,
 			Ret:     nil,
 		}
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.MultisigPropose(alice, multisigAddr, &pparams, chain.Nonce(1)),
-			chain.MustSerialize(&expected))
+			&expected)
 
 		txn0 := multisig_spec.Transaction{
 			To:       pparams.To,
@@ -117,7 +118,7 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 		td.ApplyOk(
 			td.MessageProducer.MultisigCancel(alice, multisigAddr, &multisig_spec.TxnIDParams{ID: txID0, ProposalHash: ph}, chain.Nonce(2)),
 		)
-		td.AssertMultisigState(multisigAddr, multisig_spec.State{
+		td.AssertMultisigState(multisigAddr, actors.MultisigState{
 			Signers:               []address.Address{aliceId, bobId},
 			NumApprovalsThreshold: numApprovals,
 			NextTxnID:             1,
@@ -172,9 +173,9 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 			Code:    0,
 			Ret:     nil,
 		}
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.MultisigPropose(alice, multisigAddr, &pparams, chain.Nonce(1)),
-			chain.MustSerialize(&expectedPropose))
+			&expectedPropose)
 
 		txn0 := multisig_spec.Transaction{
 			To:       pparams.To,
@@ -206,15 +207,14 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 			Code:    0,
 			Ret:     nil,
 		}
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.MultisigApprove(bob, multisigAddr, &multisig_spec.TxnIDParams{ID: txID0, ProposalHash: ph}, chain.Nonce(0)),
-			chain.MustSerialize(&expectedApprove))
+			&expectedApprove)
 
-		txID1 := multisig_spec.TxnID(1)
-		td.AssertMultisigState(multisigAddr, multisig_spec.State{
+		td.AssertMultisigState(multisigAddr, actors.MultisigState{
 			Signers:               []address.Address{aliceId, bobId},
 			NumApprovalsThreshold: numApprovals,
-			NextTxnID:             txID1,
+			NextTxnID:             1,
 			InitialBalance:        valueSend,
 			StartEpoch:            1,
 			UnlockDuration:        unlockDuration,
@@ -270,23 +270,23 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 			Code:    0,
 			Ret:     nil,
 		}
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.MultisigPropose(alice, multisigAddr, &multisig_spec.ProposeParams{
 				To:     multisigAddr,
 				Value:  big_spec.Zero(),
 				Method: builtin_spec.MethodsMultisig.AddSigner,
 				Params: chain.MustSerialize(&addSignerParams),
 			}, chain.Nonce(2)),
-			chain.MustSerialize(&expected),
+			&expected,
 		)
 
 		// TODO also exercise the approvals = 2 case with explicit approval.
 
 		// Check that bob is now a signer
-		td.AssertMultisigState(multisigAddr, multisig_spec.State{
+		td.AssertMultisigState(multisigAddr, actors.MultisigState{
 			Signers:               append(initialSigners, bobId),
 			NumApprovalsThreshold: initialNumApprovals,
-			NextTxnID:             multisig_spec.TxnID(1),
+			NextTxnID:             1,
 			InitialBalance:        big_spec.Zero(),
 			StartEpoch:            0,
 			UnlockDuration:        0,
@@ -294,7 +294,7 @@ func MessageTest_MultiSigActor(t *testing.T, factory state.Factories) {
 	})
 }
 
-func makeProposalHash(t *testing.T, txn *multisig_spec.Transaction) []byte {
+func makeProposalHash(t testing.TB, txn *multisig_spec.Transaction) []byte {
 	txnHash, err := multisig_spec.ComputeProposalHash(txn, blake2b.Sum256)
 	require.NoError(t, err)
 	return txnHash
@@ -0,0 +1,327 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	exitcode_spec "github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	"github.com/filecoin-project/specs-actors/actors/runtime"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+	"github.com/filecoin-project/chain-validation/suites/utils"
+)
+
+// Reconfigures the signers and threshold of multisig actors through proposals to themselves.
+func MessageTest_MultiSigSigners(t *testing.T, factory state.Factories) {
+	builder := drivers.NewBuilder(context.Background(), factory).
+		WithDefaultGasLimit(1_000_000_000).
+		WithDefaultGasFeeCap(200).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState()
+
+	var initialBal = abi_spec.NewTokenAmount(1_000_000_000_000)
+	var msValue = abi_spec.NewTokenAmount(100_000)
+	var valueSend = abi_spec.NewTokenAmount(10)
+
+	t.Run("remove signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, carolId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, carolId, msValue, 2, aliceId, bobId, carolId)
+
+		removeParams := multisig_spec.RemoveSignerParams{Signer: carolId, Decrease: false}
+
+		// RemoveSigner can only be invoked by the multisig itself.
+		td.ApplyFailure(
+			td.MessageProducer.MultisigRemoveSigner(alice, ms.addr, &removeParams, chain.Nonce(1)),
+			exitcode_spec.SysErrForbidden)
+
+		txn := ms.propose(aliceId, 2, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.RemoveSigner, &removeParams, multisig_spec.ProposeReturn{TxnID: 0})
+		ms.approve(bobId, 0, 0, txn, multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.Ok})
+
+		ms.assertSigners(1, 2, aliceId, bobId)
+		td.AssertMultisigContainsTransaction(ms.addr, 0, false)
+	})
+
+	t.Run("remove signer decreasing threshold", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, bobId, msValue, 2, aliceId, bobId)
+
+		removeParams := multisig_spec.RemoveSignerParams{Signer: bobId, Decrease: true}
+		txn := ms.propose(aliceId, 1, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.RemoveSigner, &removeParams, multisig_spec.ProposeReturn{TxnID: 0})
+		ms.approve(bobId, 0, 0, txn, multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.Ok})
+
+		ms.assertSigners(1, 1, aliceId)
+	})
+
+	t.Run("remove signer below threshold", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, bobId, msValue, 2, aliceId, bobId)
+
+		// removing bob without decreasing the threshold would leave fewer signers than approvals required.
+		removeParams := multisig_spec.RemoveSignerParams{Signer: bobId, Decrease: false}
+		txn := ms.propose(aliceId, 1, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.RemoveSigner, &removeParams, multisig_spec.ProposeReturn{TxnID: 0})
+		ms.approve(bobId, 0, 0, txn, multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.ErrIllegalArgument})
+
+		ms.assertSigners(1, 2, aliceId, bobId)
+		td.AssertMultisigContainsTransaction(ms.addr, 0, false)
+	})
+
+	t.Run("remove last signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, aliceId, msValue, 1, aliceId)
+
+		removeParams := multisig_spec.RemoveSignerParams{Signer: aliceId, Decrease: false}
+		ms.propose(aliceId, 1, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.RemoveSigner, &removeParams,
+			multisig_spec.ProposeReturn{TxnID: 0, Applied: true, Code: exitcode_spec.ErrForbidden})
+
+		ms.assertSigners(1, 1, aliceId)
+		td.AssertMultisigContainsTransaction(ms.addr, 0, false)
+	})
+
+	t.Run("remove non-signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, outsiderId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, outsiderId, msValue, 1, aliceId, bobId)
+
+		removeParams := multisig_spec.RemoveSignerParams{Signer: outsiderId, Decrease: false}
+		ms.propose(aliceId, 1, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.RemoveSigner, &removeParams,
+			multisig_spec.ProposeReturn{TxnID: 0, Applied: true, Code: exitcode_spec.ErrForbidden})
+
+		ms.assertSigners(1, 1, aliceId, bobId)
+	})
+
+	t.Run("swap signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		bob, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, carolId := td.NewAccountActor(drivers.SECP, initialBal)
+		outsider, outsiderId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, outsiderId, msValue, 1, aliceId, bobId)
+
+		swapParams := multisig_spec.SwapSignerParams{From: bobId, To: carolId}
+
+		// SwapSigner can only be invoked by the multisig itself.
+		td.ApplyFailure(
+			td.MessageProducer.MultisigSwapSigner(alice, ms.addr, &swapParams, chain.Nonce(1)),
+			exitcode_spec.SysErrForbidden)
+
+		ms.propose(aliceId, 2, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.SwapSigner, &swapParams,
+			multisig_spec.ProposeReturn{TxnID: 0, Applied: true, Code: exitcode_spec.Ok})
+		ms.assertSigners(1, 1, aliceId, carolId)
+
+		// bob is no longer a signer, carol is.
+		td.ApplyFailure(
+			td.MessageProducer.MultisigPropose(bob, ms.addr, &multisig_spec.ProposeParams{
+				To:     outsider,
+				Value:  valueSend,
+				Method: builtin_spec.MethodSend,
+			}, chain.Nonce(0)),
+			exitcode_spec.ErrForbidden)
+		outsiderBefore := td.GetBalance(outsiderId)
+		ms.propose(carolId, 0, outsider, valueSend, builtin_spec.MethodSend, nil,
+			multisig_spec.ProposeReturn{TxnID: 1, Applied: true, Code: exitcode_spec.Ok})
+		td.AssertBalance(outsiderId, big_spec.Add(outsiderBefore, valueSend))
+		td.AssertBalance(ms.addr, big_spec.Sub(msValue, valueSend))
+	})
+
+	t.Run("swap to existing signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, carolId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, carolId, msValue, 1, aliceId, bobId)
+
+		ms.propose(aliceId, 1, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.SwapSigner, &multisig_spec.SwapSignerParams{From: aliceId, To: bobId},
+			multisig_spec.ProposeReturn{TxnID: 0, Applied: true, Code: exitcode_spec.ErrIllegalArgument})
+		// swapping from a non-signer is forbidden.
+		ms.propose(aliceId, 2, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.SwapSigner, &multisig_spec.SwapSignerParams{From: carolId, To: bobId},
+			multisig_spec.ProposeReturn{TxnID: 1, Applied: true, Code: exitcode_spec.ErrForbidden})
+		// adding an existing signer is forbidden.
+		ms.propose(aliceId, 3, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.AddSigner, &multisig_spec.AddSignerParams{Signer: bobId, Increase: false},
+			multisig_spec.ProposeReturn{TxnID: 2, Applied: true, Code: exitcode_spec.ErrForbidden})
+
+		ms.assertSigners(3, 1, aliceId, bobId)
+	})
+
+	t.Run("change threshold", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, carolId := td.NewAccountActor(drivers.SECP, initialBal)
+		outsider, outsiderId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, outsiderId, msValue, 1, aliceId, bobId, carolId)
+
+		// ChangeNumApprovalsThreshold can only be invoked by the multisig itself.
+		td.ApplyFailure(
+			td.MessageProducer.MultisigChangeNumApprovalsThreshold(alice, ms.addr, &multisig_spec.ChangeNumApprovalsThresholdParams{NewThreshold: 2}, chain.Nonce(1)),
+			exitcode_spec.SysErrForbidden)
+
+		// thresholds must be between 1 and the number of signers.
+		ms.propose(aliceId, 2, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.ChangeNumApprovalsThreshold, &multisig_spec.ChangeNumApprovalsThresholdParams{NewThreshold: 0},
+			multisig_spec.ProposeReturn{TxnID: 0, Applied: true, Code: exitcode_spec.ErrIllegalArgument})
+		ms.propose(aliceId, 3, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.ChangeNumApprovalsThreshold, &multisig_spec.ChangeNumApprovalsThresholdParams{NewThreshold: 4},
+			multisig_spec.ProposeReturn{TxnID: 1, Applied: true, Code: exitcode_spec.ErrIllegalArgument})
+		ms.assertSigners(2, 1, aliceId, bobId, carolId)
+
+		ms.propose(aliceId, 4, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.ChangeNumApprovalsThreshold, &multisig_spec.ChangeNumApprovalsThresholdParams{NewThreshold: 3},
+			multisig_spec.ProposeReturn{TxnID: 2, Applied: true, Code: exitcode_spec.Ok})
+		ms.assertSigners(3, 3, aliceId, bobId, carolId)
+
+		// transfers now need the approval of every signer.
+		outsiderBefore := td.GetBalance(outsiderId)
+		txn := ms.propose(aliceId, 5, outsider, valueSend, builtin_spec.MethodSend, nil, multisig_spec.ProposeReturn{TxnID: 3})
+		txn = ms.approve(bobId, 0, 3, txn, multisig_spec.ApproveReturn{Applied: false})
+		td.AssertBalance(outsiderId, outsiderBefore)
+		ms.approve(carolId, 0, 3, txn, multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.Ok})
+		td.AssertBalance(outsiderId, big_spec.Add(outsiderBefore, valueSend))
+	})
+
+	t.Run("constructor with invalid threshold", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+
+		td.ApplyFailure(
+			td.MessageProducer.CreateMultisigActor(alice, []address.Address{aliceId, bobId}, 0, 0, chain.Value(msValue), chain.Nonce(0)),
+			exitcode_spec.ErrIllegalArgument)
+		td.ApplyFailure(
+			td.MessageProducer.CreateMultisigActor(alice, []address.Address{aliceId, bobId}, 0, 3, chain.Value(msValue), chain.Nonce(1)),
+			exitcode_spec.ErrIllegalArgument)
+		td.AssertNoActor(utils.NewIDAddr(t, 1+utils.IdFromAddress(bobId)))
+	})
+
+	t.Run("approval by removed signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		_, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		carol, carolId := td.NewAccountActor(drivers.SECP, initialBal)
+		outsider, outsiderId := td.NewAccountActor(drivers.SECP, initialBal)
+		ms := newSignersMultisig(td, alice, outsiderId, msValue, 2, aliceId, bobId, carolId)
+
+		// alice proposes a transfer, then the removal of carol, which bob approves.
+		transfer := ms.propose(aliceId, 1, outsider, valueSend, builtin_spec.MethodSend, nil, multisig_spec.ProposeReturn{TxnID: 0})
+		removal := ms.propose(aliceId, 2, ms.addr, big_spec.Zero(), builtin_spec.MethodsMultisig.RemoveSigner, &multisig_spec.RemoveSignerParams{Signer: carolId, Decrease: false},
+			multisig_spec.ProposeReturn{TxnID: 1})
+		ms.approve(bobId, 0, 1, removal, multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.Ok})
+		ms.assertSigners(2, 2, aliceId, bobId)
+
+		// carol can no longer approve the transfer.
+		outsiderBefore := td.GetBalance(outsiderId)
+		td.ApplyFailure(
+			td.MessageProducer.MultisigApprove(carol, ms.addr, &multisig_spec.TxnIDParams{ID: 0, ProposalHash: makeProposalHash(t, &transfer)}, chain.Nonce(0)),
+			exitcode_spec.ErrForbidden)
+		td.AssertMultisigTransaction(ms.addr, 0, transfer)
+		td.AssertBalance(outsiderId, outsiderBefore)
+
+		ms.approve(bobId, 1, 0, transfer, multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.Ok})
+		td.AssertBalance(outsiderId, big_spec.Add(outsiderBefore, valueSend))
+		td.AssertBalance(ms.addr, big_spec.Sub(msValue, valueSend))
+	})
+}
+
+// signersMultisig is a multisig actor without vesting whose signers propose and approve transactions.
+type signersMultisig struct {
+	td   *drivers.TestDriver
+	addr address.Address
+}
+
+// newSignersMultisig creates a multisig actor with `signers` and `threshold` from `creator`, whose first message it
+// is. `lastId` is the ID of the last actor created, the multisig taking the next.
+func newSignersMultisig(td *drivers.TestDriver, creator, lastId address.Address, value abi_spec.TokenAmount, threshold uint64, signers ...address.Address) *signersMultisig {
+	addr := utils.NewIDAddr(td.T, 1+utils.IdFromAddress(lastId))
+	createRet := td.ComputeInitActorExecReturn(creator, 0, 0, addr)
+	td.MustCreateAndVerifyMultisigActor(0, value, addr, creator,
+		&multisig_spec.ConstructorParams{
+			Signers:               signers,
+			NumApprovalsThreshold: threshold,
+			UnlockDuration:        0,
+		},
+		exitcode_spec.Ok, chain.MustSerialize(&createRet))
+	return &signersMultisig{td: td, addr: addr}
+}
+
+// propose proposes a transaction from the signer with ID address `from`, expecting `expected` in return, and returns the transaction as it is
+// pending after the proposal.
+func (ms *signersMultisig) propose(from address.Address, nonce uint64, to address.Address, value abi_spec.TokenAmount, method abi_spec.MethodNum, params runtime.CBORMarshaler, expected multisig_spec.ProposeReturn) multisig_spec.Transaction {
+	var ser []byte
+	if params != nil {
+		ser = chain.MustSerialize(params)
+	}
+	ms.td.ApplyExpectReturn(
+		ms.td.MessageProducer.MultisigPropose(from, ms.addr, &multisig_spec.ProposeParams{
+			To:     to,
+			Value:  value,
+			Method: method,
+			Params: ser,
+		}, chain.Nonce(nonce)),
+		&expected)
+
+	return multisig_spec.Transaction{
+		To:       to,
+		Value:    value,
+		Method:   method,
+		Params:   ser,
+		Approved: []address.Address{from},
+	}
+}
+
+// approve approves the pending transaction `txn` from the signer with ID address `from`, expecting `expected` in return, and returns the
+// transaction with the approval added.
+func (ms *signersMultisig) approve(from address.Address, nonce uint64, txnID multisig_spec.TxnID, txn multisig_spec.Transaction, expected multisig_spec.ApproveReturn) multisig_spec.Transaction {
+	ms.td.ApplyExpectReturn(
+		ms.td.MessageProducer.MultisigApprove(from, ms.addr, &multisig_spec.TxnIDParams{ID: txnID, ProposalHash: makeProposalHash(ms.td.T, &txn)}, chain.Nonce(nonce)),
+		&expected)
+
+	txn.Approved = append(txn.Approved, from)
+	return txn
+}
+
+// assertSigners asserts the multisig holds exactly `signers` and requires `threshold` approvals, after `nextTxnID`
+// transactions were proposed.
+func (ms *signersMultisig) assertSigners(nextTxnID int64, threshold uint64, signers ...address.Address) {
+	ms.td.AssertMultisigState(ms.addr, actors.MultisigState{
+		Signers:               signers,
+		NumApprovalsThreshold: threshold,
+		NextTxnID:             nextTxnID,
+		InitialBalance:        big_spec.Zero(),
+		StartEpoch:            0,
+		UnlockDuration:        0,
+	})
+}
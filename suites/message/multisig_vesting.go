@@ -0,0 +1,169 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	abi_spec "github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	exitcode_spec "github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	multisig_spec "github.com/filecoin-project/specs-actors/actors/builtin/multisig"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+	"github.com/filecoin-project/chain-validation/suites/utils"
+)
+
+// Spends from vesting multisig actors at epochs during and after their unlock duration.
+func MessageTest_MultiSigVesting(t *testing.T, factory state.Factories) {
+	builder := drivers.NewBuilder(context.Background(), factory).
+		WithDefaultGasLimit(1_000_000_000).
+		WithDefaultGasFeeCap(200).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState()
+
+	var initialBal = abi_spec.NewTokenAmount(1_000_000_000_000)
+	const unlockDuration = abi_spec.ChainEpoch(100)
+
+	t.Run("spend unlocked funds", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		// the vested balance does not divide by the unlock duration: 3 of it are never locked.
+		var msValue = abi_spec.NewTokenAmount(1_000_003)
+		var unlockedPerEpoch = abi_spec.NewTokenAmount(10_000)
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		outsider, outsiderId := td.NewAccountActor(drivers.SECP, initialBal)
+
+		multisigAddr := utils.NewIDAddr(t, 1+utils.IdFromAddress(outsiderId))
+		createRet := td.ComputeInitActorExecReturn(alice, 0, 0, multisigAddr)
+		td.MustCreateAndVerifyMultisigActor(0, msValue, multisigAddr, alice,
+			&multisig_spec.ConstructorParams{
+				Signers:               []address.Address{aliceId},
+				NumApprovalsThreshold: 1,
+				UnlockDuration:        unlockDuration,
+			},
+			exitcode_spec.Ok, chain.MustSerialize(&createRet))
+		startEpoch := td.ExeCtx.Epoch
+
+		nonce, txnID := uint64(1), multisig_spec.TxnID(0)
+		propose := func(value abi_spec.TokenAmount) *types.Message {
+			msg := td.MessageProducer.MultisigPropose(alice, multisigAddr, &multisig_spec.ProposeParams{
+				To:     outsider,
+				Value:  value,
+				Method: builtin_spec.MethodSend,
+			}, chain.Nonce(nonce))
+			nonce++
+			return msg
+		}
+
+		for _, elapsed := range []abi_spec.ChainEpoch{0, 1, 2, 25, 50, 51, 98, 99, 100, 101, 150} {
+			td.ExeCtx.Epoch = startEpoch + elapsed
+
+			remaining := unlockDuration - elapsed
+			if remaining < 0 {
+				remaining = 0
+			}
+			locked := td.MultisigLockedBalance(multisigAddr, td.ExeCtx.Epoch)
+			expectedLocked := big_spec.Mul(unlockedPerEpoch, big_spec.NewInt(int64(remaining)))
+			assert.True(t, expectedLocked.Equals(locked), "%d epochs after the start Expected Locked: %s Actual Locked: %s", elapsed, expectedLocked, locked)
+
+			msBalance := td.GetBalance(multisigAddr)
+			outsiderBalance := td.GetBalance(outsiderId)
+			available := big_spec.Sub(msBalance, locked)
+
+			// a single token more than is unlocked cannot be spent.
+			td.ApplyFailure(propose(big_spec.Add(available, big_spec.NewInt(1))), exitcode_spec.ErrInsufficientFunds)
+			td.AssertBalance(multisigAddr, msBalance)
+
+			if available.GreaterThan(big_spec.Zero()) {
+				td.ApplyExpectReturn(propose(available), &multisig_spec.ProposeReturn{
+					TxnID:   txnID,
+					Applied: true,
+					Code:    exitcode_spec.Ok,
+				})
+				txnID++
+			}
+			td.AssertBalance(multisigAddr, locked)
+			td.AssertBalance(outsiderId, big_spec.Add(outsiderBalance, available))
+		}
+		td.AssertBalance(multisigAddr, big_spec.Zero())
+	})
+
+	t.Run("approve once unlocked", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+
+		var msValue = abi_spec.NewTokenAmount(1_000_000)
+
+		alice, aliceId := td.NewAccountActor(drivers.SECP, initialBal)
+		bob, bobId := td.NewAccountActor(drivers.SECP, initialBal)
+		outsider, outsiderId := td.NewAccountActor(drivers.SECP, initialBal)
+
+		multisigAddr := utils.NewIDAddr(t, 1+utils.IdFromAddress(outsiderId))
+		createRet := td.ComputeInitActorExecReturn(alice, 0, 0, multisigAddr)
+		td.MustCreateAndVerifyMultisigActor(0, msValue, multisigAddr, alice,
+			&multisig_spec.ConstructorParams{
+				Signers:               []address.Address{aliceId, bobId},
+				NumApprovalsThreshold: 2,
+				UnlockDuration:        unlockDuration,
+			},
+			exitcode_spec.Ok, chain.MustSerialize(&createRet))
+		startEpoch := td.ExeCtx.Epoch
+		locked := td.MultisigLockedBalance(multisigAddr, startEpoch-1)
+		assert.True(t, msValue.Equals(locked), "before the start Expected Locked: %s Actual Locked: %s", msValue, locked)
+
+		// proposing the whole balance is allowed while it is locked, the funds are only checked once approved.
+		td.ExeCtx.Epoch = startEpoch + 1
+		pparams := multisig_spec.ProposeParams{
+			To:     outsider,
+			Value:  msValue,
+			Method: builtin_spec.MethodSend,
+		}
+		td.ApplyExpectReturn(
+			td.MessageProducer.MultisigPropose(alice, multisigAddr, &pparams, chain.Nonce(1)),
+			&multisig_spec.ProposeReturn{TxnID: 0, Applied: false})
+		txn := multisig_spec.Transaction{
+			To:       pparams.To,
+			Value:    pparams.Value,
+			Method:   pparams.Method,
+			Params:   pparams.Params,
+			Approved: []address.Address{aliceId},
+		}
+		approveParams := multisig_spec.TxnIDParams{ID: 0, ProposalHash: makeProposalHash(t, &txn)}
+
+		// half way through the unlock duration half of the balance is still locked.
+		td.ExeCtx.Epoch = startEpoch + unlockDuration/2
+		locked = td.MultisigLockedBalance(multisigAddr, td.ExeCtx.Epoch)
+		assert.True(t, big_spec.Div(msValue, big_spec.NewInt(2)).Equals(locked), "Expected Locked: %s Actual Locked: %s", big_spec.Div(msValue, big_spec.NewInt(2)), locked)
+		td.ApplyFailure(
+			td.MessageProducer.MultisigApprove(bob, multisigAddr, &approveParams, chain.Nonce(0)),
+			exitcode_spec.ErrInsufficientFunds)
+		td.AssertMultisigTransaction(multisigAddr, 0, txn)
+		td.AssertBalance(multisigAddr, msValue)
+
+		// the last epoch of the unlock duration still locks a share of the balance.
+		td.ExeCtx.Epoch = startEpoch + unlockDuration - 1
+		td.ApplyFailure(
+			td.MessageProducer.MultisigApprove(bob, multisigAddr, &approveParams, chain.Nonce(1)),
+			exitcode_spec.ErrInsufficientFunds)
+		td.AssertMultisigTransaction(multisigAddr, 0, txn)
+
+		td.ExeCtx.Epoch = startEpoch + unlockDuration
+		locked = td.MultisigLockedBalance(multisigAddr, td.ExeCtx.Epoch)
+		assert.True(t, locked.IsZero(), "Expected Locked: 0 Actual Locked: %s", locked)
+		outsiderBalance := td.GetBalance(outsiderId)
+		td.ApplyExpectReturn(
+			td.MessageProducer.MultisigApprove(bob, multisigAddr, &approveParams, chain.Nonce(2)),
+			&multisig_spec.ApproveReturn{Applied: true, Code: exitcode_spec.Ok})
+		td.AssertMultisigContainsTransaction(multisigAddr, 0, false)
+		td.AssertBalance(multisigAddr, big_spec.Zero())
+		td.AssertBalance(outsiderId, big_spec.Add(outsiderBalance, msValue))
+	})
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/builtin/multisig"
 	"github.com/filecoin-project/specs-actors/actors/builtin/paych"
 	"github.com/filecoin-project/specs-actors/actors/builtin/reward"
-	"github.com/filecoin-project/specs-actors/actors/puppet"
 	"github.com/filecoin-project/specs-actors/actors/runtime"
 	"github.com/filecoin-project/go-state-types/exitcode"
 	"github.com/filecoin-project/specs-actors/actors/util/adt"
@@ -29,17 +28,6 @@ import (
 	"github.com/filecoin-project/chain-validation/suites/utils"
 )
 
-var PuppetAddress address.Address
-
-func init() {
-	var err error
-	// the address before the burnt funds address
-	PuppetAddress, err = address.NewIDAddress(builtin.FirstNonSingletonActorId - 2)
-	if err != nil {
-		panic(err)
-	}
-}
-
 // Tests exercising messages sent internally from one actor to another.
 // These use a multisig actor with approvers=1 as a convenient staging ground for arbitrary internal messages.
 func MessageTest_NestedSends(t *testing.T, factory state.Factories) {
@@ -51,7 +39,14 @@ func MessageTest_NestedSends(t *testing.T, factory state.Factories) {
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
+
+	puppetBuilder := drivers.NewBuilder(context.Background(), factory).
+		WithDefaultGasLimit(1_000_000_000).
+		WithDefaultGasFeeCap(200).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState().
+		WithPuppetActor()
 
 	t.Run("ok basic", func(t *testing.T) {
 		td := builder.Build(t)
@@ -311,7 +306,7 @@ func MessageTest_NestedSends(t *testing.T, factory state.Factories) {
 			To:   builtin.SystemActorAddr,
 		}
 		execParams := init_.ExecParams{
-			CodeCID:           builtin.PaymentChannelActorCodeID,
+			CodeCID:           td.Actors().Codes().PaymentChannel,
 			ConstructorParams: chain.MustSerialize(&ctorParams),
 		}
 
@@ -324,41 +319,92 @@ func MessageTest_NestedSends(t *testing.T, factory state.Factories) {
 
 	t.Run("fail insufficient funds for transfer in inner send", func(t *testing.T) {
 		// puppet actor has zero funds
-		puppetBalance := big.Zero()
-		td := builder.WithActorState(drivers.ActorState{
-			Addr:    PuppetAddress,
-			Balance: puppetBalance,
-			Code:    puppet.PuppetActorCodeID,
-			State:   &puppet.State{},
-		}).Build(t)
+		td := puppetBuilder.Build(t)
 		defer td.Complete()
 
 		alice, _ := td.NewAccountActor(drivers.SECP, acctDefaultBalance)
 		bob, _ := td.NewAccountActor(drivers.SECP, big.Zero())
 
-		// alice tells the puppet actor to send funds to bob, the puppet actor has 0 balance so the inner send will fail,
-		// and alice will pay the gas cost.
+		// alice tells the puppet actor to send funds to bob without sending it any, the puppet actor has 0 balance so
+		// the inner send will fail, and alice will pay the gas cost.
 		amtSent := abi.NewTokenAmount(1)
-		result := td.ApplyMessage(td.MessageProducer.PuppetSend(alice, PuppetAddress, &puppet.SendParams{
-			To:     bob,
-			Value:  amtSent,
-			Method: builtin.MethodSend,
-			Params: nil,
-		}))
-
-		// the outer message should be applied successfully
-		assert.Equal(t, exitcode.Ok, result.Receipt.ExitCode)
-
-		var puppetRet puppet.SendReturn
-		chain.MustDeserialize(result.Receipt.ReturnValue, &puppetRet)
+		result, returns := td.Puppet().Send(bob, amtSent).Apply(alice, chain.Value(big.Zero()))
 
 		// the inner message should fail
-		assert.Equal(t, exitcode.SysErrInsufficientFunds, puppetRet.Code)
+		require.Len(t, returns, 1)
+		assert.Equal(t, exitcode.SysErrInsufficientFunds, returns[0].Code)
 
 		// alice should be charged for the gas cost and bob should have not received any funds.
 		td.AssertActorChange(alice, acctDefaultBalance, result.Msg.GasLimit, result.Msg.GasPremium, big.Zero(), result.Receipt, 1)
 		td.AssertBalance(bob, big.Zero())
+	})
 
+	t.Run("ok puppet recursion", func(t *testing.T) {
+		td := puppetBuilder.Build(t)
+		defer td.Complete()
+
+		alice, _ := td.NewAccountActor(drivers.SECP, acctDefaultBalance)
+		bob, _ := td.NewAccountActor(drivers.SECP, big.Zero())
+
+		// the puppet sends the funds alice sends it to itself three times before sending them to bob.
+		amtSent := abi.NewTokenAmount(1)
+		result, returns := td.Puppet().Recurse(3).Send(bob, amtSent).Apply(alice)
+
+		require.Len(t, returns, 4)
+		for i, ret := range returns {
+			assert.Equal(t, exitcode.Ok, ret.Code, "send of puppet %d failed", i)
+		}
+		td.AssertActorChange(alice, acctDefaultBalance, result.Msg.GasLimit, result.Msg.GasPremium, amtSent, result.Receipt, 1)
+		td.AssertBalance(drivers.PuppetAddress, big.Zero())
+		td.AssertBalance(bob, amtSent)
+	})
+
+	t.Run("fail nonexistent ID address in recursion", func(t *testing.T) {
+		td := puppetBuilder.Build(t)
+		defer td.Complete()
+
+		alice, _ := td.NewAccountActor(drivers.SECP, acctDefaultBalance)
+
+		// only the innermost send fails, the puppets relaying it return its exit code.
+		newAddr := utils.NewIDAddr(t, 1234)
+		_, returns := td.Puppet().Recurse(2).Send(newAddr, big.Zero()).Apply(alice)
+
+		require.Len(t, returns, 3)
+		assert.Equal(t, exitcode.Ok, returns[0].Code)
+		assert.Equal(t, exitcode.Ok, returns[1].Code)
+		assert.Equal(t, exitcode.SysErrInvalidReceiver, returns[2].Code)
+		td.AssertNoActor(newAddr)
+	})
+
+	t.Run("fail puppet failures rolled back", func(t *testing.T) {
+		for _, tc := range []struct {
+			desc   string
+			script func(s *drivers.PuppetScript, to address.Address) *drivers.PuppetScript
+		}{
+			{"params", func(s *drivers.PuppetScript, to address.Address) *drivers.PuppetScript {
+				return s.FailParams(to, builtin.MethodsAccount.PubkeyAddress)
+			}},
+			{"return", func(s *drivers.PuppetScript, _ address.Address) *drivers.PuppetScript { return s.FailReturn() }},
+			{"state write", func(s *drivers.PuppetScript, _ address.Address) *drivers.PuppetScript { return s.FailStateWrite() }},
+		} {
+			t.Run(tc.desc, func(t *testing.T) {
+				td := puppetBuilder.Build(t)
+				defer td.Complete()
+
+				alice, _ := td.NewAccountActor(drivers.SECP, acctDefaultBalance)
+				bob, _ := td.NewAccountActor(drivers.SECP, big.Zero())
+				puppetHead := td.GetHead(drivers.PuppetAddress)
+
+				// the last puppet's call to itself fails, the exit code of an encoding failure being left to the
+				// implementation, and the puppets relaying it return its exit code.
+				_, returns := tc.script(td.Puppet().Recurse(1), bob).Apply(alice)
+
+				require.Len(t, returns, 2)
+				assert.Equal(t, exitcode.Ok, returns[0].Code)
+				assert.NotEqual(t, exitcode.Ok, returns[1].Code)
+				td.AssertHead(drivers.PuppetAddress, puppetHead)
+			})
+		}
 	})
 
 	// TODO more tests:
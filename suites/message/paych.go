@@ -1,18 +1,25 @@
 package message
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/filecoin-project/go-address"
 	abi_spec "github.com/filecoin-project/go-state-types/abi"
 	big_spec "github.com/filecoin-project/go-state-types/big"
-	paych_spec "github.com/filecoin-project/specs-actors/actors/builtin/paych"
 	crypto_spec "github.com/filecoin-project/go-state-types/crypto"
+	exitcode_spec "github.com/filecoin-project/go-state-types/exitcode"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	paych_spec "github.com/filecoin-project/specs-actors/actors/builtin/paych"
 	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/minio/blake2b-simd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/types"
 	"github.com/filecoin-project/chain-validation/drivers"
 	"github.com/filecoin-project/chain-validation/state"
 	"github.com/filecoin-project/chain-validation/suites/utils"
@@ -23,7 +30,7 @@ func MessageTest_Paych(t *testing.T, factory state.Factories) {
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	var initialBal = abi_spec.NewTokenAmount(1_000_000_000_000)
 	var toSend = abi_spec.NewTokenAmount(10_000)
@@ -42,9 +49,9 @@ func MessageTest_Paych(t *testing.T, factory state.Factories) {
 		createRet := td.ComputeInitActorExecReturn(sender, 0, 0, paychAddr)
 
 		// init actor creates the payment channel
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(0)),
-			chain.MustSerialize(&createRet))
+			&createRet)
 
 		var pcState paych_spec.State
 		td.GetActorState(paychAddr, &pcState)
@@ -75,9 +82,9 @@ func MessageTest_Paych(t *testing.T, factory state.Factories) {
 		// the _expected_ address of the payment channel
 		paychAddr := utils.NewIDAddr(t, utils.IdFromAddress(receiverID)+1)
 		createRet := td.ComputeInitActorExecReturn(sender, 0, 0, paychAddr)
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(0)),
-			chain.MustSerialize(&createRet))
+			&createRet)
 
 		td.ApplyOk(
 			td.MessageProducer.PaychUpdateChannelState(sender, paychAddr, &paych_spec.UpdateChannelStateParams{
@@ -120,9 +127,9 @@ func MessageTest_Paych(t *testing.T, factory state.Factories) {
 		receiver, receiverID := td.NewAccountActor(drivers.SECP, initialBal)
 		paychAddr := utils.NewIDAddr(t, utils.IdFromAddress(receiverID)+1)
 		initRet := td.ComputeInitActorExecReturn(sender, 0, 0, paychAddr)
-		td.ApplyExpect(
+		td.ApplyExpectReturn(
 			td.MessageProducer.CreatePaymentChannelActor(sender, receiver, chain.Value(toSend), chain.Nonce(0)),
-			chain.MustSerialize(&initRet))
+			&initRet)
 		td.AssertBalance(paychAddr, toSend)
 
 		td.ApplyOk(
@@ -164,4 +171,376 @@ func MessageTest_Paych(t *testing.T, factory state.Factories) {
 		td.AssertNoActor(paychAddr)
 	})
 
+	t.Run("lanes", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		// vouchers on distinct lanes add up.
+		pc.updateOk(pc.voucher(1, 1, abi_spec.NewTokenAmount(10)))
+		pc.updateOk(pc.voucher(2, 1, abi_spec.NewTokenAmount(20)))
+		pc.updateOk(pc.voucher(7, 3, abi_spec.NewTokenAmount(5)))
+		pc.assertLanes(map[uint64]paych_spec.LaneState{
+			1: {Redeemed: abi_spec.NewTokenAmount(10), Nonce: 1},
+			2: {Redeemed: abi_spec.NewTokenAmount(20), Nonce: 1},
+			7: {Redeemed: abi_spec.NewTokenAmount(5), Nonce: 3},
+		})
+		pc.assertToSend(abi_spec.NewTokenAmount(35))
+
+		// a later voucher on a lane replaces the amount redeemed from it.
+		pc.updateOk(pc.voucher(1, 2, abi_spec.NewTokenAmount(15)))
+		pc.assertLanes(map[uint64]paych_spec.LaneState{
+			1: {Redeemed: abi_spec.NewTokenAmount(15), Nonce: 2},
+			2: {Redeemed: abi_spec.NewTokenAmount(20), Nonce: 1},
+			7: {Redeemed: abi_spec.NewTokenAmount(5), Nonce: 3},
+		})
+		pc.assertToSend(abi_spec.NewTokenAmount(40))
+	})
+
+	t.Run("stale nonce", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		pc.updateOk(pc.voucher(1, 2, abi_spec.NewTokenAmount(10)))
+		pc.update(pc.voucher(1, 2, abi_spec.NewTokenAmount(20)), nil, exitcode_spec.ErrIllegalArgument)
+		pc.update(pc.voucher(1, 1, abi_spec.NewTokenAmount(20)), nil, exitcode_spec.ErrIllegalArgument)
+
+		pc.assertLanes(map[uint64]paych_spec.LaneState{
+			1: {Redeemed: abi_spec.NewTokenAmount(10), Nonce: 2},
+		})
+		pc.assertToSend(abi_spec.NewTokenAmount(10))
+	})
+
+	t.Run("lane merges", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		pc.updateOk(pc.voucher(1, 1, abi_spec.NewTokenAmount(10)))
+		pc.updateOk(pc.voucher(2, 1, abi_spec.NewTokenAmount(20)))
+
+		// lane 2 merges into lane 1: the voucher amount covers what both lanes redeemed so far.
+		sv := pc.voucher(1, 2, abi_spec.NewTokenAmount(50))
+		sv.Merges = []paych_spec.Merge{{Lane: 2, Nonce: 2}}
+		pc.updateOk(sv)
+		pc.assertLanes(map[uint64]paych_spec.LaneState{
+			1: {Redeemed: abi_spec.NewTokenAmount(50), Nonce: 2},
+			2: {Redeemed: abi_spec.NewTokenAmount(20), Nonce: 2},
+		})
+		pc.assertToSend(abi_spec.NewTokenAmount(50))
+
+		// a lane cannot merge into itself.
+		sv = pc.voucher(1, 3, abi_spec.NewTokenAmount(60))
+		sv.Merges = []paych_spec.Merge{{Lane: 1, Nonce: 3}}
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		// merged lanes must exist.
+		sv = pc.voucher(1, 3, abi_spec.NewTokenAmount(60))
+		sv.Merges = []paych_spec.Merge{{Lane: 9, Nonce: 1}}
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		// merges carry a nonce above that of the merged lane.
+		sv = pc.voucher(1, 3, abi_spec.NewTokenAmount(60))
+		sv.Merges = []paych_spec.Merge{{Lane: 2, Nonce: 2}}
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		pc.assertLanes(map[uint64]paych_spec.LaneState{
+			1: {Redeemed: abi_spec.NewTokenAmount(50), Nonce: 2},
+			2: {Redeemed: abi_spec.NewTokenAmount(20), Nonce: 2},
+		})
+		pc.assertToSend(abi_spec.NewTokenAmount(50))
+	})
+
+	t.Run("voucher amounts", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		pc.update(pc.voucher(1, 1, big_spec.Add(toSend, big_spec.NewInt(1))), nil, exitcode_spec.ErrIllegalArgument)
+		pc.update(pc.voucher(1, 1, abi_spec.NewTokenAmount(-1)), nil, exitcode_spec.ErrIllegalArgument)
+		pc.updateOk(pc.voucher(1, 1, toSend))
+		pc.assertToSend(toSend)
+		td.AssertBalance(pc.addr, toSend)
+	})
+
+	t.Run("time locks", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+		td.ExeCtx.Epoch = 100
+
+		// vouchers cannot be redeemed before their minimum time lock.
+		sv := pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.TimeLockMin = td.ExeCtx.Epoch + 1
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		// nor after their maximum time lock.
+		sv = pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.TimeLockMax = td.ExeCtx.Epoch - 1
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		// both bounds are inclusive.
+		sv = pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.TimeLockMin = td.ExeCtx.Epoch
+		sv.TimeLockMax = td.ExeCtx.Epoch
+		pc.updateOk(sv)
+		pc.assertToSend(abi_spec.NewTokenAmount(10))
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		secret := []byte("open sesame")
+		hashed := blake2b.Sum256(secret)
+		sv := pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.SecretPreimage = hashed[:]
+
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+		pc.update(sv, []byte("open barley"), exitcode_spec.ErrIllegalArgument)
+		pc.update(sv, secret, exitcode_spec.Ok)
+		pc.assertToSend(abi_spec.NewTokenAmount(10))
+	})
+
+	t.Run("extra data", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		// the voucher is only redeemed if the actor method it names succeeds: the update aborts with the exit code of
+		// the failed call, here to a method the account actor does not have.
+		sv := pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.Extra = &paych_spec.ModVerifyParams{
+			Actor:  pc.fromId,
+			Method: abi_spec.MethodNum(99),
+		}
+		pc.update(sv, nil, exitcode_spec.SysErrInvalidMethod)
+		pc.assertLanes(map[uint64]paych_spec.LaneState{})
+		pc.assertToSend(big_spec.Zero())
+
+		sv = pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.Extra = &paych_spec.ModVerifyParams{
+			Actor:  pc.fromId,
+			Method: builtin_spec.MethodsAccount.PubkeyAddress,
+		}
+		pc.updateOk(sv)
+		pc.assertToSend(abi_spec.NewTokenAmount(10))
+	})
+
+	t.Run("wrong signer", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+		outsider, _ := td.NewAccountActor(drivers.SECP, initialBal)
+
+		// vouchers submitted by the receiver must be signed by the sender.
+		sv := pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.Signature = pc.signedBy(pc.to)
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		sv = pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.Signature = pc.signedBy(outsider)
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		sv = pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.Signature = nil
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		// vouchers name the channel they are redeemed from.
+		sv = pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.ChannelAddr = pc.fromId
+		pc.update(sv, nil, exitcode_spec.ErrIllegalArgument)
+
+		// only the parties of the channel can redeem vouchers.
+		td.ApplyFailure(
+			td.MessageProducer.PaychUpdateChannelState(outsider, pc.addr, &paych_spec.UpdateChannelStateParams{
+				Sv: pc.voucher(1, 1, abi_spec.NewTokenAmount(10)),
+			}, chain.Nonce(0), chain.Value(big_spec.Zero())),
+			exitcode_spec.SysErrForbidden)
+
+		pc.assertToSend(big_spec.Zero())
+	})
+
+	t.Run("settle then collect", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+		redeemed := abi_spec.NewTokenAmount(1_000)
+		pc.updateOk(pc.voucher(1, 1, redeemed))
+
+		// collecting requires settling first.
+		pc.collect(exitcode_spec.ErrForbidden)
+
+		settleEpoch := td.ExeCtx.Epoch
+		td.ApplyOk(td.MessageProducer.PaychSettle(pc.from, pc.addr, nil, chain.Value(big_spec.Zero()), chain.Nonce(pc.nextFromNonce())))
+		assert.Equal(t, settleEpoch+paych_spec.SettleDelay, pc.state().SettlingAt)
+		td.ApplyFailure(
+			td.MessageProducer.PaychSettle(pc.from, pc.addr, nil, chain.Value(big_spec.Zero()), chain.Nonce(pc.nextFromNonce())),
+			exitcode_spec.ErrIllegalState)
+
+		// the channel cannot be collected until the settling period is over.
+		td.ExeCtx.Epoch = settleEpoch + paych_spec.SettleDelay - 1
+		pc.collect(exitcode_spec.ErrForbidden)
+		td.AssertBalance(pc.addr, toSend)
+
+		td.ExeCtx.Epoch = settleEpoch + paych_spec.SettleDelay
+		fromBalance, toBalance := td.GetBalance(pc.fromId), td.GetBalance(pc.toId)
+		result := pc.collect(exitcode_spec.Ok)
+		td.AssertBalance(pc.fromId, big_spec.Add(fromBalance, big_spec.Sub(toSend, redeemed)))
+		td.AssertActorChange(pc.toId, toBalance, result.Msg.GasLimit, result.Msg.GasPremium, redeemed.Neg(), result.Receipt, pc.toNonce)
+		td.AssertNoActor(pc.addr)
+	})
+
+	t.Run("min settle height", func(t *testing.T) {
+		td := builder.Build(t)
+		defer td.Complete()
+		pc := newPaychStage(td, initialBal, toSend)
+
+		settleEpoch := td.ExeCtx.Epoch
+		minSettleHeight := settleEpoch + paych_spec.SettleDelay + 100
+		sv := pc.voucher(1, 1, abi_spec.NewTokenAmount(10))
+		sv.MinSettleHeight = minSettleHeight
+		pc.updateOk(sv)
+		assert.Equal(t, minSettleHeight, pc.state().MinSettleHeight)
+
+		// the channel settles at the minimum settle height rather than after the settle delay.
+		td.ApplyOk(td.MessageProducer.PaychSettle(pc.from, pc.addr, nil, chain.Value(big_spec.Zero()), chain.Nonce(pc.nextFromNonce())))
+		assert.Equal(t, minSettleHeight, pc.state().SettlingAt)
+
+		// a voucher redeemed while settling delays settlement to its own minimum settle height.
+		sv = pc.voucher(2, 1, abi_spec.NewTokenAmount(10))
+		sv.MinSettleHeight = minSettleHeight + 10
+		pc.updateOk(sv)
+		assert.Equal(t, minSettleHeight+10, pc.state().SettlingAt)
+		assert.Equal(t, minSettleHeight+10, pc.state().MinSettleHeight)
+
+		td.ExeCtx.Epoch = minSettleHeight
+		pc.collect(exitcode_spec.ErrForbidden)
+		td.ExeCtx.Epoch = minSettleHeight + 10
+		pc.collect(exitcode_spec.Ok)
+		td.AssertNoActor(pc.addr)
+	})
+}
+
+// paychStage is a payment channel from one account to another, holding vouchers signed by the sender and redeemed
+// by the receiver. Signatures of vouchers carry the key address of their signer, and the syscalls of the driver are
+// replaced to reject any other.
+type paychStage struct {
+	td *drivers.TestDriver
+
+	from, fromId address.Address
+	to, toId     address.Address
+	addr         address.Address
+
+	// the nonces of the next messages from each party
+	fromNonce, toNonce uint64
+}
+
+// newPaychStage creates a payment channel between two new accounts with `balance`, funded with `value`.
+func newPaychStage(td *drivers.TestDriver, balance, value abi_spec.TokenAmount) *paychStage {
+	from, fromId := td.NewAccountActor(drivers.SECP, balance)
+	to, toId := td.NewAccountActor(drivers.SECP, balance)
+	addr := utils.NewIDAddr(td.T, utils.IdFromAddress(toId)+1)
+	createRet := td.ComputeInitActorExecReturn(from, 0, 0, addr)
+	td.ApplyExpectReturn(
+		td.MessageProducer.CreatePaymentChannelActor(from, to, chain.Value(value), chain.Nonce(0)),
+		&createRet)
+
+	keys := map[address.Address]address.Address{from: from, fromId: from, to: to, toId: to}
+	td.SysCalls.VerifySigFunc = func(sig crypto_spec.Signature, signer address.Address, _ []byte) error {
+		if key, ok := keys[signer]; !ok || !bytes.Equal(sig.Data, key.Bytes()) {
+			return fmt.Errorf("signature not made by %s", signer)
+		}
+		return nil
+	}
+
+	return &paychStage{
+		td:        td,
+		from:      from,
+		fromId:    fromId,
+		to:        to,
+		toId:      toId,
+		addr:      addr,
+		fromNonce: 1,
+	}
+}
+
+func (pc *paychStage) signedBy(signer address.Address) *crypto_spec.Signature {
+	return &crypto_spec.Signature{
+		Type: crypto_spec.SigTypeSecp256k1,
+		Data: signer.Bytes(),
+	}
+}
+
+// voucher returns a voucher for the channel signed by its sender.
+func (pc *paychStage) voucher(lane, nonce uint64, amount abi_spec.TokenAmount) paych_spec.SignedVoucher {
+	return paych_spec.SignedVoucher{
+		ChannelAddr: pc.addr,
+		Lane:        lane,
+		Nonce:       nonce,
+		Amount:      amount,
+		Signature:   pc.signedBy(pc.from),
+	}
+}
+
+func (pc *paychStage) nextFromNonce() uint64 {
+	pc.fromNonce++
+	return pc.fromNonce - 1
+}
+
+func (pc *paychStage) nextToNonce() uint64 {
+	pc.toNonce++
+	return pc.toNonce - 1
+}
+
+// update redeems `sv` with `secret` from the receiver of the channel, expecting `code`.
+func (pc *paychStage) update(sv paych_spec.SignedVoucher, secret []byte, code exitcode_spec.ExitCode) {
+	pc.td.ApplyFailure(
+		pc.td.MessageProducer.PaychUpdateChannelState(pc.to, pc.addr, &paych_spec.UpdateChannelStateParams{
+			Sv:     sv,
+			Secret: secret,
+		}, chain.Nonce(pc.nextToNonce()), chain.Value(big_spec.Zero())),
+		code)
+}
+
+func (pc *paychStage) updateOk(sv paych_spec.SignedVoucher) {
+	pc.update(sv, nil, exitcode_spec.Ok)
+}
+
+// collect collects the channel from its receiver, expecting `code`.
+func (pc *paychStage) collect(code exitcode_spec.ExitCode) types.ApplyMessageResult {
+	return pc.td.ApplyFailure(
+		pc.td.MessageProducer.PaychCollect(pc.to, pc.addr, nil, chain.Nonce(pc.nextToNonce()), chain.Value(big_spec.Zero())),
+		code)
+}
+
+func (pc *paychStage) state() *paych_spec.State {
+	var st paych_spec.State
+	pc.td.GetActorState(pc.addr, &st)
+	return &st
+}
+
+func (pc *paychStage) assertToSend(expected abi_spec.TokenAmount) {
+	actual := pc.state().ToSend
+	assert.True(pc.td.T, expected.Equals(actual), "Expected ToSend: %s Actual ToSend: %s", expected, actual)
+}
+
+// assertLanes asserts the channel holds exactly the lanes of `expected`, keyed by lane ID.
+func (pc *paychStage) assertLanes(expected map[uint64]paych_spec.LaneState) {
+	arr, err := adt.AsArray(drivers.AsStore(pc.td.State()), pc.state().LaneStates)
+	require.NoError(pc.td.T, err)
+	assert.EqualValues(pc.td.T, len(expected), arr.Length(), "Expected %d lanes Actual %d lanes", len(expected), arr.Length())
+
+	for id, exp := range expected {
+		var ls paych_spec.LaneState
+		found, err := arr.Get(id, &ls)
+		require.NoError(pc.td.T, err)
+		if !assert.True(pc.td.T, found, "lane %d not found", id) {
+			continue
+		}
+		assert.Equal(pc.td.T, exp.Nonce, ls.Nonce, "lane %d Expected Nonce: %d Actual Nonce: %d", id, exp.Nonce, ls.Nonce)
+		assert.True(pc.td.T, exp.Redeemed.Equals(ls.Redeemed), "lane %d Expected Redeemed: %s Actual Redeemed: %s", id, exp.Redeemed, ls.Redeemed)
+	}
 }
@@ -7,11 +7,10 @@ import (
 	address "github.com/filecoin-project/go-address"
 	abi_spec "github.com/filecoin-project/go-state-types/abi"
 	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/go-state-types/network"
 	require "github.com/stretchr/testify/require"
 
 	big_spec "github.com/filecoin-project/go-state-types/big"
-	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
-	account_spec "github.com/filecoin-project/specs-actors/actors/builtin/account"
 
 	chain "github.com/filecoin-project/chain-validation/chain"
 	"github.com/filecoin-project/chain-validation/drivers"
@@ -30,7 +29,7 @@ type valueTransferTestCases struct {
 	receiver    address.Address
 	receiverBal big_spec.Int
 
-	code exitcode.ExitCode
+	result *drivers.VersionedResult
 }
 
 func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
@@ -43,7 +42,7 @@ func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
 		WithDefaultGasLimit(gasLimit).
 		WithDefaultGasFeeCap(gasFeeCap).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	testCases := []valueTransferTestCases{
 		{
@@ -57,7 +56,7 @@ func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
 			receiver:    bob,
 			receiverBal: big_spec.Zero(),
 
-			code: exitcode.Ok,
+			result: drivers.ExpectResult(exitcode.Ok, drivers.EmptyReturnValue),
 		},
 		{
 			desc: "successfully transfer zero funds from sender to receiver",
@@ -70,7 +69,7 @@ func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
 			receiver:    bob,
 			receiverBal: big_spec.Zero(),
 
-			code: exitcode.Ok,
+			result: drivers.ExpectResult(exitcode.Ok, drivers.EmptyReturnValue),
 		},
 		{
 			desc: "fail to transfer more funds than sender balance > 0",
@@ -83,7 +82,7 @@ func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
 			receiver:    bob,
 			receiverBal: big_spec.Zero(),
 
-			code: exitcode.SysErrInsufficientFunds,
+			result: drivers.ExpectResult(exitcode.SysErrInsufficientFunds, drivers.EmptyReturnValue),
 		},
 		{
 			desc: "fail to transfer more funds than sender has when sender balance matches gas limit",
@@ -96,7 +95,7 @@ func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
 			receiver:    bob,
 			receiverBal: big_spec.Zero(),
 
-			code: exitcode.SysErrInsufficientFunds,
+			result: drivers.ExpectResult(exitcode.SysErrInsufficientFunds, drivers.EmptyReturnValue),
 		},
 		{
 			desc: "fail to transfer when sender balance under gas limit",
@@ -109,43 +108,46 @@ func MessageTest_ValueTransferSimple(t *testing.T, factories state.Factories) {
 			receiver:    bob,
 			receiverBal: big_spec.Zero(),
 
-			code: exitcode.SysErrSenderStateInvalid,
+			result: drivers.ExpectResult(exitcode.SysErrSenderStateInvalid, drivers.EmptyReturnValue),
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			td := builder.Build(t)
-			defer td.Complete()
-
-			// Create the to and from actors with balance in the state tree
-			_, _, err := td.State().CreateActor(builtin_spec.AccountActorCodeID, tc.sender, tc.senderBal, &account_spec.State{Address: tc.sender})
-			require.NoError(t, err)
-			if tc.sender.String() != tc.receiver.String() {
-				_, _, err := td.State().CreateActor(builtin_spec.AccountActorCodeID, tc.receiver, tc.receiverBal, &account_spec.State{Address: tc.receiver})
+			drivers.NetworkVersions(network.Version0, network.Version3).Run(t, func(t *testing.T, nv network.Version) {
+				td := builder.Copy().WithNetworkVersion(nv).Build(t)
+				defer td.Complete()
+
+				// Create the to and from actors with balance in the state tree
+				_, _, err := td.State().CreateActor(td.Actors().Codes().Account, tc.sender, tc.senderBal, td.Actors().NewAccountState(tc.sender))
+				require.NoError(t, err)
+				if tc.sender.String() != tc.receiver.String() {
+					_, _, err := td.State().CreateActor(td.Actors().Codes().Account, tc.receiver, tc.receiverBal, td.Actors().NewAccountState(tc.receiver))
+					require.NoError(t, err)
+				}
+
+				sendAct, err := td.State().Actor(tc.sender)
 				require.NoError(t, err)
-			}
-
-			sendAct, err := td.State().Actor(tc.sender)
-			require.NoError(t, err)
-			require.Equal(t, tc.senderBal.String(), sendAct.Balance().String())
-
-			result := td.ApplyFailure(
-				td.MessageProducer.Transfer(tc.sender, tc.receiver, chain.Value(tc.transferAmnt), chain.Nonce(0)),
-				tc.code,
-			)
-			// create a message to transfer funds from `to` to `from` for amount `transferAmnt` and apply it to the state tree
-			// assert the actor balances changed as expected, the receiver balance should not change if transfer fails
-			if tc.code.IsSuccess() {
-				td.AssertActorChange(tc.sender, tc.senderBal, result.Msg.GasLimit, result.Msg.GasPremium, tc.transferAmnt, result.Receipt, 1)
-				td.AssertBalance(tc.receiver, tc.transferAmnt)
-			} else {
-				if tc.code == exitcode.SysErrInsufficientFunds {
-					td.AssertActorChange(tc.sender, tc.senderBal, result.Msg.GasLimit, result.Msg.GasPremium, big_spec.Zero(), result.Receipt, 1)
+				require.Equal(t, tc.senderBal.String(), sendAct.Balance().String())
+
+				result := td.ApplyExpectVersioned(
+					td.MessageProducer.Transfer(tc.sender, tc.receiver, chain.Value(tc.transferAmnt), chain.Nonce(0)),
+					tc.result,
+				)
+				// create a message to transfer funds from `to` to `from` for amount `transferAmnt` and apply it to the state tree
+				// assert the actor balances changed as expected, the receiver balance should not change if transfer fails
+				code := tc.result.At(nv).ExitCode
+				if code.IsSuccess() {
+					td.AssertActorChange(tc.sender, tc.senderBal, result.Msg.GasLimit, result.Msg.GasPremium, tc.transferAmnt, result.Receipt, 1)
+					td.AssertBalance(tc.receiver, tc.transferAmnt)
 				} else {
-					td.AssertBalance(tc.sender, tc.senderBal)
+					if code == exitcode.SysErrInsufficientFunds {
+						td.AssertActorChange(tc.sender, tc.senderBal, result.Msg.GasLimit, result.Msg.GasPremium, big_spec.Zero(), result.Receipt, 1)
+					} else {
+						td.AssertBalance(tc.sender, tc.senderBal)
+					}
 				}
-			}
+			})
 		})
 	}
 }
@@ -157,7 +159,7 @@ func MessageTest_ValueTransferAdvance(t *testing.T, factory state.Factories) {
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	t.Run("self transfer secp to secp", func(t *testing.T) {
 		td := builder.Build(t)
@@ -0,0 +1,190 @@
+package tipset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	market_spec "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	miner_spec "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+// Builds the chain from a genesis spec with accounts, a vesting multisig and a miner with pre-sealed sectors, and
+// asserts the miner's power claim, sectors and deals are installed as if the sectors had been committed on chain.
+// The genesis miner then mines the first tipsets.
+func TipSetTest_Genesis(t *testing.T, factory state.Factories) {
+	const gasLimit = 1_000_000_000
+	const gasFeeCap = 200
+	const preSeals = 2
+
+	accountBalance := abi.NewTokenAmount(10_000_000_000_000)
+	multisigBalance := abi.NewTokenAmount(1_000_000)
+
+	spec := drivers.GenesisSpec{
+		Accounts: []drivers.GenesisAccount{
+			{Name: "alice", Type: address.SECP256K1, Balance: accountBalance},
+			{Name: "bob", Type: address.SECP256K1, Balance: accountBalance},
+			{Name: "worker", Type: address.BLS, Balance: accountBalance},
+		},
+		Multisigs: []drivers.GenesisMultisig{
+			{Name: "vault", Signers: []string{"alice", "bob"}, Threshold: 2, Balance: multisigBalance, UnlockDuration: 10},
+		},
+		Miners: []drivers.GenesisMiner{
+			{Name: "miner", Owner: "alice", Worker: "worker", PreSeals: preSeals, DealClient: "bob"},
+		},
+	}
+
+	td := drivers.NewBuilder(context.Background(), factory).
+		WithDefaultGasLimit(gasLimit).
+		WithDefaultGasFeeCap(gasFeeCap).
+		WithDefaultGasPremium(1).
+		WithDefaultBuiltinActorsState().
+		WithGenesis(spec).
+		Build(t)
+	defer td.Complete()
+
+	if td.Actors().Version() != actors.Version0 {
+		t.Skip("genesis states are decoded with the v0 schemas")
+	}
+	store := drivers.AsStore(td.State())
+	g := td.Genesis
+	minerID := g.ID("miner")
+	require.Equal(t, minerID, td.ExeCtx.Miner, "the first genesis miner mines")
+
+	// accounts and the multisig hold their balances.
+	td.AssertBalance(g.ID("alice"), accountBalance)
+	td.AssertBalance(g.ID("bob"), accountBalance)
+	td.AssertBalance(g.ID("vault"), multisigBalance)
+	td.AssertMultisigState(g.ID("vault"), actors.MultisigState{
+		Signers:               []address.Address{g.ID("alice"), g.ID("bob")},
+		NumApprovalsThreshold: 2,
+		InitialBalance:        multisigBalance,
+		UnlockDuration:        10,
+	})
+
+	preseals := g.Sectors.MinerSectors[minerID]
+	require.Len(t, preseals, preSeals)
+	ssize, err := preseals[0].ProofType.SectorSize()
+	require.NoError(t, err)
+
+	// the miner holds the sectors, assigned to deadlines, from the start of its proving period.
+	var minerSt miner_spec.State
+	td.GetActorState(minerID, &minerSt)
+	assert.Equal(t, drivers.GenesisProvingPeriodStart, minerSt.ProvingPeriodStart)
+	deadlines, err := minerSt.LoadDeadlines(store)
+	require.NoError(t, err)
+	liveSectors := uint64(0)
+	for i := uint64(0); i < miner_spec.WPoStPeriodDeadlines; i++ {
+		dl, err := deadlines.LoadDeadline(store, i)
+		require.NoError(t, err)
+		liveSectors += dl.LiveSectors
+	}
+	assert.Equal(t, uint64(preSeals), liveSectors)
+
+	var dealIDs []abi.DealID
+	qaPower := big.Zero()
+	for _, preseal := range preseals {
+		sector, found, err := minerSt.GetSector(store, preseal.SectorID)
+		require.NoError(t, err)
+		require.True(t, found, "sector %d", preseal.SectorID)
+		assert.Equal(t, preseal.CommR, sector.SealedCID)
+		assert.Equal(t, preseal.Deal.EndEpoch, sector.Expiration)
+		require.Len(t, sector.DealIDs, 1)
+		dealIDs = append(dealIDs, sector.DealIDs[0])
+		qaPower = big.Add(qaPower, miner_spec.QAPowerForSector(ssize, sector))
+	}
+
+	// the power actor claims the power of the sectors for the miner and calls it to handle its proving deadlines.
+	var powerSt power_spec.State
+	td.GetActorState(builtin.StoragePowerActorAddr, &powerSt)
+	claims, err := adt.AsMap(store, powerSt.Claims)
+	require.NoError(t, err)
+	var claim power_spec.Claim
+	found, err := claims.Get(adt.AddrKey(minerID), &claim)
+	require.NoError(t, err)
+	require.True(t, found, "no power claim for the genesis miner")
+	rawPower := big.Mul(big.NewInt(preSeals), big.NewIntUnsigned(uint64(ssize)))
+	assert.Equal(t, rawPower, claim.RawBytePower)
+	assert.Equal(t, qaPower, claim.QualityAdjPower)
+	assert.True(t, powerSt.TotalRawBytePower.GreaterThanEqual(rawPower))
+
+	events, err := adt.AsMultimap(store, powerSt.CronEventQueue)
+	require.NoError(t, err)
+	var event power_spec.CronEvent
+	var enrolled []address.Address
+	require.NoError(t, events.ForEach(abi.IntKey(int64(drivers.GenesisProvingPeriodStart-1)), &event, func(int64) error {
+		enrolled = append(enrolled, event.MinerAddr)
+		return nil
+	}))
+	assert.Contains(t, enrolled, minerID)
+
+	// the market holds the deals, active since genesis and scheduled for its cron.
+	var marketSt market_spec.State
+	td.GetActorState(builtin.StorageMarketActorAddr, &marketSt)
+	proposals, err := adt.AsArray(store, marketSt.Proposals)
+	require.NoError(t, err)
+	dealStates, err := adt.AsArray(store, marketSt.States)
+	require.NoError(t, err)
+	pending, err := adt.AsMap(store, marketSt.PendingProposals)
+	require.NoError(t, err)
+	ops, err := market_spec.AsSetMultimap(store, marketSt.DealOpsByEpoch)
+	require.NoError(t, err)
+	for i, id := range dealIDs {
+		var proposal market_spec.DealProposal
+		found, err := proposals.Get(uint64(id), &proposal)
+		require.NoError(t, err)
+		require.True(t, found, "no proposal for deal %d", id)
+		assert.Equal(t, preseals[i].Deal, proposal)
+		assert.Equal(t, minerID, proposal.Provider)
+		assert.Equal(t, g.ID("bob"), proposal.Client)
+
+		var dealSt market_spec.DealState
+		found, err = dealStates.Get(uint64(id), &dealSt)
+		require.NoError(t, err)
+		require.True(t, found, "no state for deal %d", id)
+		assert.Equal(t, abi.ChainEpoch(0), dealSt.SectorStartEpoch)
+		assert.Equal(t, abi.ChainEpoch(-1), dealSt.SlashEpoch)
+
+		pcid, err := proposal.Cid()
+		require.NoError(t, err)
+		var pendingProposal market_spec.DealProposal
+		found, err = pending.Get(abi.CidKey(pcid), &pendingProposal)
+		require.NoError(t, err)
+		assert.True(t, found, "proposal of deal %d is not pending", id)
+
+		opEpoch := proposal.StartEpoch
+		if opEpoch <= marketSt.LastCron {
+			opEpoch = marketSt.LastCron + 1
+		}
+		scheduled := false
+		require.NoError(t, ops.ForEach(opEpoch, func(op abi.DealID) error {
+			scheduled = scheduled || op == id
+			return nil
+		}))
+		assert.True(t, scheduled, "deal %d is not scheduled at epoch %d", id, opEpoch)
+	}
+
+	// the genesis miner mines, and its owner can spend from genesis.
+	td.ApplyEmptyTipSets(3)
+	td.ApplyOk(td.MessageProducer.Transfer(g.Robust("alice"), g.ID("bob"), chain.Value(big.NewInt(1)), chain.Nonce(0)))
+
+	td.GetActorState(builtin.StoragePowerActorAddr, &powerSt)
+	claims, err = adt.AsMap(store, powerSt.Claims)
+	require.NoError(t, err)
+	found, err = claims.Get(adt.AddrKey(minerID), &claim)
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, rawPower, claim.RawBytePower, "the miner lost power before its proving period started")
+}
@@ -23,7 +23,7 @@ func TipSetTest_BlockMessageApplication(t *testing.T, factory state.Factories) {
 		WithDefaultGasLimit(gasLimit).
 		WithDefaultGasFeeCap(gasFeeCap).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	t.Run("SECP and BLS messages cost different amounts of gas", func(t *testing.T) {
 		td := builder.Build(t)
@@ -61,7 +61,7 @@ func TipSetTest_BlockMessageDeduplication(t *testing.T, factory state.Factories)
 		WithDefaultGasLimit(gasLimit).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(1).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	t.Run("apply a single BLS message", func(t *testing.T) {
 		td := builder.Build(t)
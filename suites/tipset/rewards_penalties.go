@@ -28,7 +28,7 @@ func TipSetTest_MinerRewardsAndPenalties(t *testing.T, factory state.Factories)
 		WithDefaultGasLimit(1_000_000_000).
 		WithDefaultGasFeeCap(200).
 		WithDefaultGasPremium(gasPremium).
-		WithActorState(drivers.DefaultBuiltinActorsState...)
+		WithDefaultBuiltinActorsState()
 
 	acctDefaultBalance := abi.NewTokenAmount(10_000_000_000_000)
 	sendValue := abi.NewTokenAmount(1)
@@ -266,12 +266,12 @@ func TipSetTest_MinerRewardsAndPenalties(t *testing.T, factory state.Factories)
 		// to test insufficient gas to cover return value.
 		tracerResult := tb.WithBlockBuilder(
 			drivers.NewBlockBuilder(td, td.ExeCtx.Miner).
-				WithBLSMessageAndRet(td.MessageProducer.MinerControlAddresses(alice, miner, nil, chain.Nonce(0)),
+				WithBLSMessageAndReturn(td.MessageProducer.MinerControlAddresses(alice, miner, nil, chain.Nonce(0)),
 					// required to satisfy testing methods, unrelated to current test.
-					chain.MustSerialize(&miner_spec.GetControlAddressesReturn{
+					&miner_spec.GetControlAddressesReturn{
 						Owner:  td.StateDriver.BuiltinMinerInfo().OwnerID,
 						Worker: td.StateDriver.BuiltinMinerInfo().WorkerID,
-					}),
+					},
 				),
 		).ApplyAndValidate()
 		requiredGasLimit := tracerResult.Receipts[0].GasUsed
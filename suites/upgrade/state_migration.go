@@ -0,0 +1,175 @@
+package upgrade
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	big_spec "github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+	builtin_spec "github.com/filecoin-project/specs-actors/actors/builtin"
+	market_spec "github.com/filecoin-project/specs-actors/actors/builtin/market"
+	miner_spec "github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	power_spec "github.com/filecoin-project/specs-actors/actors/builtin/power"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/chain"
+	"github.com/filecoin-project/chain-validation/chain/actors"
+	"github.com/filecoin-project/chain-validation/chain/types"
+	"github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/chain-validation/state"
+)
+
+type stateMigrationTestCase struct {
+	desc string
+
+	upgrade state.Upgrade
+}
+
+// Builds state under the genesis network and actors versions, crosses an upgrade by applying tipsets, and asserts the
+// state the migration produced: every actor keeps its balance and call sequence number and has its code replaced
+// with that of the actors version of the upgrade, and a multisig keeps its configuration. Miner, market and power
+// structures are compared using the schemas of the v0 actors, for upgrades keeping them. The state root after the
+// upgrade is validated against the recorded expectations.
+// An upgrade is migrated to each registered actors version after the default one. The v0 actors are the only version
+// released, so unless an implementation's tests register another the migration between actors versions is skipped.
+func UpgradeTest_StateMigration(t *testing.T, factory state.Factories) {
+	const gasLimit = 1_000_000_000
+	const gasFeeCap = 200
+	const upgradeHeight = abi.ChainEpoch(3)
+
+	testCases := []stateMigrationTestCase{
+		{
+			desc:    "upgrade without an actors version change preserves actors",
+			upgrade: state.Upgrade{Height: upgradeHeight, Network: network.Version1, Actors: actors.DefaultVersion},
+		},
+	}
+	for _, v := range actors.Versions() {
+		if v > actors.DefaultVersion {
+			testCases = append(testCases, stateMigrationTestCase{
+				desc:    fmt.Sprintf("upgrade to actors v%d migrates actors", v),
+				upgrade: state.Upgrade{Height: upgradeHeight, Network: network.Version1, Actors: v},
+			})
+		}
+	}
+	if len(testCases) == 1 {
+		t.Run("upgrade across actors versions migrates actors", func(t *testing.T) {
+			t.Skipf("skipping test: actors version %d is the only one registered, so no migration between actors versions can be validated", actors.DefaultVersion)
+		})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			from, err := actors.Get(actors.DefaultVersion)
+			require.NoError(t, err)
+			to, err := actors.Get(tc.upgrade.Actors)
+			require.NoError(t, err)
+			codes := actors.MigratedCodes(from.Codes(), to.Codes())
+
+			td := drivers.NewBuilder(context.Background(), factory).
+				WithDefaultGasLimit(gasLimit).
+				WithDefaultGasFeeCap(gasFeeCap).
+				WithDefaultGasPremium(1).
+				WithDefaultBuiltinActorsState().
+				WithActorsVersion(from.Version()).
+				WithNetworkVersion(types.DefaultNetworkVersion).
+				WithUpgradeSchedule(tc.upgrade).
+				WithGenesis(drivers.GenesisSpec{
+					Accounts: []drivers.GenesisAccount{{Name: "signer", Type: address.SECP256K1, Balance: big_spec.Zero()}},
+					Multisigs: []drivers.GenesisMultisig{{
+						Name:           "multisig",
+						Signers:        []string{"signer"},
+						Threshold:      1,
+						Balance:        big_spec.NewInt(1_000),
+						UnlockDuration: 100,
+					}},
+				}).
+				Build(t)
+			defer td.Complete()
+
+			alice, aliceId := td.NewAccountActor(address.SECP256K1, big_spec.NewInt(10*gasFeeCap*gasLimit))
+			_, bobId := td.NewAccountActor(address.BLS, big_spec.Zero())
+			td.ApplyOk(td.MessageProducer.Transfer(alice, bobId, chain.Value(big_spec.NewInt(100)), chain.Nonce(0)))
+
+			// build up to the epoch before the upgrade.
+			td.ApplyEmptyTipSets(tc.upgrade.Height - 1)
+			assert.Equal(t, types.DefaultNetworkVersion, td.ExeCtx.NetworkVersion)
+
+			minerInfo := td.BuiltinMinerInfo()
+			multisigId := td.Genesis.ID("multisig")
+			before := td.SnapshotActors(
+				builtin_spec.SystemActorAddr,
+				builtin_spec.InitActorAddr,
+				builtin_spec.CronActorAddr,
+				builtin_spec.StoragePowerActorAddr,
+				builtin_spec.StorageMarketActorAddr,
+				aliceId,
+				bobId,
+				minerInfo.OwnerID,
+				minerInfo.WorkerID,
+				multisigId,
+			)
+			minerCode := td.SnapshotActors(td.ExeCtx.Miner)[td.ExeCtx.Miner].Code
+			prevMultisig, err := td.Actors().LoadMultisig(drivers.AsStore(td.State()), td.GetHead(multisigId))
+			require.NoError(t, err)
+			// miner, market and power structures are compared using the schemas of the v0 actors.
+			var prevMiner miner_spec.State
+			td.GetActorState(td.ExeCtx.Miner, &prevMiner)
+			prevMinerInfo, err := prevMiner.GetInfo(drivers.AsStore(td.State()))
+			require.NoError(t, err)
+			var prevMarket market_spec.State
+			td.GetActorState(builtin_spec.StorageMarketActorAddr, &prevMarket)
+			var prevPower power_spec.State
+			td.GetActorState(builtin_spec.StoragePowerActorAddr, &prevPower)
+
+			// cross the upgrade.
+			td.ApplyEmptyTipSets(tc.upgrade.Height)
+			assert.Equal(t, tc.upgrade.Network, td.ExeCtx.NetworkVersion)
+
+			assert.Equal(t, to.Version(), td.Actors().Version())
+
+			td.AssertActorsMigrated(before, codes)
+			assert.Equal(t, codes[minerCode], td.SnapshotActors(td.ExeCtx.Miner)[td.ExeCtx.Miner].Code)
+			// the multisig keeps its signers and vesting, read with the actors of the upgrade.
+			td.AssertMultisigState(multisigId, *prevMultisig)
+
+			// messages apply under the new network version.
+			td.ApplyOk(td.MessageProducer.Transfer(alice, bobId, chain.Value(big_spec.NewInt(100)), chain.Nonce(1)))
+			td.AssertBalance(bobId, big_spec.NewInt(200))
+
+			if to.Version() != actors.Version0 {
+				return
+			}
+
+			// the builtin miner keeps its identity and configuration.
+			var postMiner miner_spec.State
+			td.GetActorState(td.ExeCtx.Miner, &postMiner)
+			postMinerInfo, err := postMiner.GetInfo(drivers.AsStore(td.State()))
+			require.NoError(t, err)
+			assert.Equal(t, prevMinerInfo.Owner, postMinerInfo.Owner)
+			assert.Equal(t, prevMinerInfo.Worker, postMinerInfo.Worker)
+			assert.Equal(t, prevMinerInfo.SealProofType, postMinerInfo.SealProofType)
+			assert.Equal(t, prevMinerInfo.SectorSize, postMinerInfo.SectorSize)
+			assert.Equal(t, prevMiner.Sectors, postMiner.Sectors)
+			assert.Equal(t, prevMiner.PreCommittedSectors, postMiner.PreCommittedSectors)
+
+			// market and power keep their tables.
+			var postMarket market_spec.State
+			td.GetActorState(builtin_spec.StorageMarketActorAddr, &postMarket)
+			assert.Equal(t, prevMarket.Proposals, postMarket.Proposals)
+			assert.Equal(t, prevMarket.States, postMarket.States)
+			assert.Equal(t, prevMarket.EscrowTable, postMarket.EscrowTable)
+			assert.Equal(t, prevMarket.LockedTable, postMarket.LockedTable)
+			assert.Equal(t, prevMarket.NextID, postMarket.NextID)
+
+			var postPower power_spec.State
+			td.GetActorState(builtin_spec.StoragePowerActorAddr, &postPower)
+			assert.Equal(t, prevPower.MinerCount, postPower.MinerCount)
+			assert.Equal(t, prevPower.Claims, postPower.Claims)
+			assert.Equal(t, prevPower.TotalRawBytePower.String(), postPower.TotalRawBytePower.String())
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/filecoin-project/chain-validation/state"
+	"github.com/filecoin-project/chain-validation/suites/fuzz"
 	"github.com/filecoin-project/chain-validation/suites/message"
 	"github.com/filecoin-project/chain-validation/suites/tipset"
+	"github.com/filecoin-project/chain-validation/suites/upgrade"
 )
 
 type TestCase func(t *testing.T, factory state.Factories)
@@ -13,9 +15,13 @@ type TestCase func(t *testing.T, factory state.Factories)
 func MessageTestCases() []TestCase {
 	return []TestCase{
 		message.MessageTest_AccountActorCreation,
+		message.MessageTest_CallerAuthorization,
+		message.MessageTest_InitActorExec,
 		message.MessageTest_InitActorSequentialIDAddressCreate,
 		message.MessageTest_MessageApplicationEdgecases,
 		message.MessageTest_MultiSigActor,
+		message.MessageTest_MultiSigSigners,
+		message.MessageTest_MultiSigVesting,
 		message.MessageTest_NestedSends,
 		message.MessageTest_Paych,
 		message.MessageTest_ValueTransferAdvance,
@@ -27,6 +33,29 @@ func TipSetTestCases() []TestCase {
 	return []TestCase{
 		tipset.TipSetTest_BlockMessageApplication,
 		tipset.TipSetTest_BlockMessageDeduplication,
+		tipset.TipSetTest_Genesis,
 		tipset.TipSetTest_MinerRewardsAndPenalties,
 	}
 }
+
+func UpgradeTestCases() []TestCase {
+	return []TestCase{
+		upgrade.UpgradeTest_StateMigration,
+	}
+}
+
+func FuzzTestCases() []TestCase {
+	return []TestCase{
+		fuzz.FuzzTest_RandomSequences,
+		fuzz.FuzzTest_MessageParams,
+	}
+}
+
+// DifferentialTestCase compares the implementations of two factories, without recorded expectations.
+type DifferentialTestCase func(t *testing.T, factoryA, factoryB state.Factories)
+
+func DifferentialTestCases() []DifferentialTestCase {
+	return []DifferentialTestCase{
+		fuzz.DifferentialTest_RandomSequences,
+	}
+}
@@ -0,0 +1,146 @@
+package tracker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestKey identifies the expectations recorded for a single test. It mirrors the suite/case/subtest hierarchy
+// of the test name so that distinct tests never share an entry, and it can be converted back to the test name.
+type TestKey struct {
+	// Suite is the family a test case belongs to, e.g. "MessageTest".
+	Suite string
+	// Case is the name of the test case within its suite, e.g. "ValueTransferSimple".
+	Case string
+	// Subtests holds the subtest names as reported by testing.T.Name(), outermost first.
+	Subtests []string
+}
+
+// matches the suffix the testing package appends to subtests sharing a name with a sibling.
+var duplicateSubtestSuffix = regexp.MustCompile(`#[0-9]+$`)
+
+// KeyFromTest returns the key of the test `t`, failing the test if its name does not follow the
+// <Suite>_<Case> convention used by chain-validation test cases.
+func KeyFromTest(t testing.TB) TestKey {
+	key, err := KeyFromTestName(t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// KeyFromTestName returns the key for a full test name as reported by testing.T.Name(). The first element of the
+// name is the implementation's harness test (e.g. TestChainValidationMessageSuite) and is not part of the key.
+func KeyFromTestName(name string) (TestKey, error) {
+	tokens := strings.Split(name, "/")
+	if len(tokens) < 2 {
+		return TestKey{}, fmt.Errorf("test name %q has no test case element", name)
+	}
+	suiteCase := strings.SplitN(tokens[1], "_", 2)
+	if len(suiteCase) != 2 || suiteCase[0] == "" || suiteCase[1] == "" {
+		return TestKey{}, fmt.Errorf("test case %q does not follow the <Suite>_<Case> naming convention", tokens[1])
+	}
+	return TestKey{
+		Suite:    suiteCase[0],
+		Case:     suiteCase[1],
+		Subtests: tokens[2:],
+	}, nil
+}
+
+// ParseKey parses the string form of a key, as produced by TestKey.String.
+func ParseKey(s string) (TestKey, error) {
+	if !strings.HasPrefix(s, "/") {
+		return TestKey{}, fmt.Errorf("key %q must begin with '/'", s)
+	}
+	var comps []string
+	for _, c := range strings.Split(s[1:], "/") {
+		u, err := unescapeComponent(c)
+		if err != nil {
+			return TestKey{}, fmt.Errorf("key %q: %w", s, err)
+		}
+		comps = append(comps, u)
+	}
+	if len(comps) < 2 || comps[0] == "" || comps[1] == "" {
+		return TestKey{}, fmt.Errorf("key %q must contain a suite and a case", s)
+	}
+	return TestKey{
+		Suite:    comps[0],
+		Case:     comps[1],
+		Subtests: comps[2:],
+	}, nil
+}
+
+// String returns the hierarchical form of the key, e.g. "/MessageTest/ValueTransferSimple/successfully_transfer".
+// Each element is escaped so the result is safe to use as a relative file path.
+func (k TestKey) String() string {
+	sb := strings.Builder{}
+	for _, c := range append([]string{k.Suite, k.Case}, k.Subtests...) {
+		sb.WriteByte('/')
+		sb.WriteString(escapeComponent(c))
+	}
+	return sb.String()
+}
+
+// TestName returns the name of the test the key was derived from, without the harness test element.
+func (k TestKey) TestName() string {
+	return strings.Join(append([]string{k.Suite + "_" + k.Case}, k.Subtests...), "/")
+}
+
+// IsDuplicateSubtest returns true if the testing package had to disambiguate the innermost subtest name from a
+// sibling with the same name. Such tests cannot be told apart between runs and must not record expectations.
+func (k TestKey) IsDuplicateSubtest() bool {
+	if len(k.Subtests) == 0 {
+		return false
+	}
+	return duplicateSubtestSuffix.MatchString(k.Subtests[len(k.Subtests)-1])
+}
+
+// LegacyKey returns the key a test name was stored under before keys were hierarchical: every character other
+// than letters and numbers was dropped. Distinct tests can share a legacy key, it is only useful for migration.
+func LegacyKey(name string) string {
+	reg := regexp.MustCompile("[^a-zA-Z0-9]+")
+	tokens := strings.Split(name, "/")
+	chainvalTestName := strings.Join(tokens[1:], "")
+	return fmt.Sprintf("/%s", reg.ReplaceAllString(chainvalTestName, ""))
+}
+
+// escapeComponent percent-encodes every byte of `c` other than letters, numbers, '-', and non-leading '_' and '.'.
+// Leading '_' and '.' are escaped since go:embed skips files whose names start with them.
+func escapeComponent(c string) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(c); i++ {
+		b := c[i]
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9', b == '-':
+			sb.WriteByte(b)
+		case (b == '.' || b == '_') && i > 0:
+			sb.WriteByte(b)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
+
+func unescapeComponent(c string) (string, error) {
+	sb := strings.Builder{}
+	for i := 0; i < len(c); i++ {
+		if c[i] != '%' {
+			sb.WriteByte(c[i])
+			continue
+		}
+		if i+2 >= len(c) {
+			return "", fmt.Errorf("truncated escape sequence in %q", c)
+		}
+		b, err := strconv.ParseUint(c[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape sequence in %q: %w", c, err)
+		}
+		sb.WriteByte(byte(b))
+		i += 2
+	}
+	return sb.String(), nil
+}
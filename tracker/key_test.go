@@ -0,0 +1,75 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyFromTestName(t *testing.T) {
+	key, err := KeyFromTestName("TestChainValidationMessageSuite/MessageTest_ValueTransferSimple/fail_to_transfer_more_funds_than_sender_balance_>_0")
+	require.NoError(t, err)
+	assert.Equal(t, TestKey{
+		Suite:    "MessageTest",
+		Case:     "ValueTransferSimple",
+		Subtests: []string{"fail_to_transfer_more_funds_than_sender_balance_>_0"},
+	}, key)
+	assert.Equal(t, "/MessageTest/ValueTransferSimple/fail_to_transfer_more_funds_than_sender_balance_%3E_0", key.String())
+
+	_, err = KeyFromTestName("TestChainValidationMessageSuite/NoSuiteSeparator")
+	assert.Error(t, err)
+	_, err = KeyFromTestName("TestChainValidationMessageSuite")
+	assert.Error(t, err)
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	for _, name := range []string{
+		"Harness/MessageTest_Paych",
+		"Harness/MessageTest_Paych/happy_path_collect",
+		"Harness/TipSetTest_MinerRewardsAndPenalties/penalize_sender_doesn't_exist",
+		"Harness/MessageTest_NestedSends/.hidden/100%_done/ünïcode",
+	} {
+		key, err := KeyFromTestName(name)
+		require.NoError(t, err)
+
+		parsed, err := ParseKey(key.String())
+		require.NoError(t, err)
+		assert.Equal(t, key, parsed)
+		assert.Equal(t, name[len("Harness/"):], parsed.TestName())
+	}
+}
+
+func TestKeyDistinguishesLegacyCollisions(t *testing.T) {
+	a := "Harness/MessageTest_Foo/a-b"
+	b := "Harness/MessageTest_Foo/ab"
+	require.Equal(t, LegacyKey(a), LegacyKey(b))
+
+	keyA, err := KeyFromTestName(a)
+	require.NoError(t, err)
+	keyB, err := KeyFromTestName(b)
+	require.NoError(t, err)
+	assert.NotEqual(t, keyA.String(), keyB.String())
+}
+
+func TestKeyIsDuplicateSubtest(t *testing.T) {
+	key, err := KeyFromTestName("Harness/MessageTest_Foo/case#01")
+	require.NoError(t, err)
+	assert.True(t, key.IsDuplicateSubtest())
+
+	key, err = KeyFromTestName("Harness/MessageTest_Foo/case")
+	require.NoError(t, err)
+	assert.False(t, key.IsDuplicateSubtest())
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	for _, s := range []string{
+		"MessageTest/Foo",
+		"/MessageTest",
+		"/MessageTest/Foo/bad%2",
+		"/MessageTest/Foo/bad%ZZ",
+	} {
+		_, err := ParseKey(s)
+		assert.Error(t, err, s)
+	}
+}
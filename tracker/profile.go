@@ -0,0 +1,73 @@
+package tracker
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/filecoin-project/chain-validation/box"
+)
+
+// Resolution reports the profile the expectations of a test were loaded from.
+type Resolution struct {
+	// Key of the test.
+	Key string
+	// Profiles that were searched, in order.
+	Profiles []string
+	// Profile the expectations were found in, empty if none of the profiles had expectations for the test.
+	Profile string
+}
+
+// Fallback returns true if the expectations were not found in the most specific profile.
+func (r Resolution) Fallback() bool {
+	return r.Profile != "" && r.Profile != r.Profiles[0]
+}
+
+// resolutions holds the resolution of every test run by this process.
+var resolutions = struct {
+	sync.Mutex
+	list []Resolution
+}{}
+
+// Resolutions returns the profile resolution of every test run by this process so far, ordered by key and profiles.
+func Resolutions() []Resolution {
+	resolutions.Lock()
+	defer resolutions.Unlock()
+	out := make([]Resolution, len(resolutions.list))
+	copy(out, resolutions.list)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Key != out[j].Key {
+			return out[i].Key < out[j].Key
+		}
+		return strings.Join(out[i].Profiles, ",") < strings.Join(out[j].Profiles, ",")
+	})
+	return out
+}
+
+func addResolution(r Resolution) {
+	resolutions.Lock()
+	defer resolutions.Unlock()
+	resolutions.list = append(resolutions.list, r)
+}
+
+// ResolveProfiles returns the profiles to search for expectations, most specific first. ProfileEnvVar, a comma
+// separated list of profiles, overrides the `configured` profiles when set. The default profile is always searched last.
+func ResolveProfiles(configured []string) []string {
+	if env := os.Getenv(ProfileEnvVar); env != "" {
+		configured = strings.Split(env, ",")
+	}
+
+	var profiles []string
+	seen := make(map[string]bool)
+	candidates := append(append([]string{}, configured...), box.DefaultProfile)
+	for _, p := range candidates {
+		p = strings.TrimSpace(p)
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		profiles = append(profiles, p)
+	}
+	return profiles
+}
@@ -0,0 +1,50 @@
+package tracker
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/chain-validation/box"
+)
+
+func TestResolveProfiles(t *testing.T) {
+	assert.Equal(t, []string{box.DefaultProfile}, ResolveProfiles(nil))
+	assert.Equal(t, []string{"nv5", "v2", box.DefaultProfile}, ResolveProfiles([]string{"nv5", "v2", "nv5"}))
+
+	require.NoError(t, os.Setenv(ProfileEnvVar, "nv6, default"))
+	defer os.Unsetenv(ProfileEnvVar) // nolint: errcheck
+	assert.Equal(t, []string{"nv6", box.DefaultProfile}, ResolveProfiles([]string{"nv5"}))
+}
+
+func TestLoadDataForTestFallsBack(t *testing.T) {
+	exp := `{"version":1,"results":[{"message":{"Receipt":{"ExitCode":0,"ReturnValue":"","GasUsed":1},"Penalty":"0","Reward":"0","Root":"bafy2bzacebk6xuroukj2tcbapzyqrmjmhzsfolivoevbga6qhinb5x7ouesmy"}}]}`
+	store := box.NewStore(fstest.MapFS{
+		"v2/MessageTest/Foo.json": {Data: []byte(exp)},
+	})
+
+	t.Run("MessageTest_Foo", func(t *testing.T) {
+		results, profile := LoadDataForTest(t, store, []string{"nv5", "v2", box.DefaultProfile})
+		assert.Equal(t, "v2", profile)
+		require.Len(t, results, 1)
+		assert.EqualValues(t, 1, results[0].Message.GasUsed())
+	})
+	t.Run("MessageTest_Bar", func(t *testing.T) {
+		results, profile := LoadDataForTest(t, store, []string{"nv5", box.DefaultProfile})
+		assert.Equal(t, "", profile)
+		assert.Empty(t, results)
+	})
+
+	var found []Resolution
+	for _, r := range Resolutions() {
+		if r.Key == "/MessageTest/Foo" || r.Key == "/MessageTest/Bar" {
+			found = append(found, r)
+		}
+	}
+	require.Len(t, found, 2)
+	assert.Equal(t, "", found[0].Profile)
+	assert.True(t, found[1].Fallback())
+}
@@ -2,157 +2,174 @@ package tracker
 
 import (
 	"container/list"
-	"encoding/json"
-	"fmt"
 	"os"
-	"path/filepath"
-	"regexp"
-	"strings"
+	"sync"
 	"testing"
 
-	"github.com/ipfs/go-cid"
-
 	"github.com/filecoin-project/chain-validation/box"
 	"github.com/filecoin-project/chain-validation/chain/types"
 )
 
+// ValidationDataEnvVar is the directory expectations are recorded to, laid out like the box resources directory.
 const ValidationDataEnvVar = "CHAIN_VALIDATION_DATA"
 
+// ExpectationsDirEnvVar optionally points the tracker at a directory of expectations to validate against instead of
+// those embedded in the box package.
+const ExpectationsDirEnvVar = "CHAIN_VALIDATION_EXPECTATIONS"
+
+// ProfileEnvVar optionally overrides the expectation profiles selected by the validation config with a comma separated
+// list of profiles, most specific first.
+const ProfileEnvVar = "CHAIN_VALIDATION_PROFILE"
+
+// recorded maps the profile paths of the keys recorded by this process to the name of the test that recorded them.
+var recorded = struct {
+	sync.Mutex
+	keys map[string]string
+}{keys: make(map[string]string)}
+
 type StateTracker struct {
 	tracker *list.List
 	T       testing.TB
 
-	// index in gasUnits of expected gas
-	gasIdx int
-	// slice of gas units used by the test
-	expectedGasUnits []types.GasUnits
+	// profiles searched for expectations, most specific first. Results are recorded to the first.
+	profiles []string
+	// profile the expectations were loaded from, empty if none were found
+	profile string
 
-	// index in stateroots of expected cid
-	rootIdx int
-	// slice of state roots used by the test
-	expectedStateRoots []cid.Cid
+	// index in expected of the next result
+	idx int
+	// results recorded for the test, in the order they were applied
+	expected []box.Result
 }
 
-func NewStateTracker(t testing.TB) *StateTracker {
-	gasUsed, stateRoots := LoadDataForTest(t)
+// NewStateTracker returns a tracker validating against the first of `profiles` holding expectations for `t`. See
+// ResolveProfiles for the fallback rules.
+func NewStateTracker(t testing.TB, profiles []string) *StateTracker {
+	profiles = ResolveProfiles(profiles)
+	expected, profile := LoadDataForTest(t, ExpectationStore(), profiles)
 	return &StateTracker{
-		tracker:            list.New(),
-		T:                  t,
-		gasIdx:             0,
-		expectedGasUnits:   gasUsed,
-		rootIdx:            0,
-		expectedStateRoots: stateRoots,
+		tracker:  list.New(),
+		T:        t,
+		profiles: profiles,
+		profile:  profile,
+		idx:      0,
+		expected: expected,
 	}
 }
 
+// Profile returns the profile expectations were loaded from, empty if none were found.
+func (st *StateTracker) Profile() string {
+	return st.profile
+}
+
 func (st *StateTracker) TrackResult(result types.Trackable) {
 	st.tracker.PushBack(result)
 }
 
-func (st *StateTracker) NextExpectedGas() (types.GasUnits, bool) {
-	defer func() { st.gasIdx += 1 }()
-	if st.gasIdx > len(st.expectedGasUnits)-1 {
-		// didn't find any gas
-		return 0, false
+// NextExpectedMessageResult returns the recorded result of the next message applied by the test.
+// Found is false if there is no recorded result, or the recorded result is not that of a message.
+func (st *StateTracker) NextExpectedMessageResult() (*types.ApplyMessageResult, bool) {
+	res, found := st.next()
+	if !found {
+		return nil, false
+	}
+	if res.Message == nil {
+		st.T.Logf("WARNING: expected result %d is not the result of a message", st.idx-1)
+		return nil, false
+	}
+	return res.Message, true
+}
+
+// NextExpectedTipSetResult returns the recorded result of the next tipset applied by the test.
+// Found is false if there is no recorded result, or the recorded result is not that of a tipset.
+func (st *StateTracker) NextExpectedTipSetResult() (*types.ApplyTipSetResult, bool) {
+	res, found := st.next()
+	if !found {
+		return nil, false
 	}
-	return st.expectedGasUnits[st.gasIdx], true
+	if res.TipSet == nil {
+		st.T.Logf("WARNING: expected result %d is not the result of a tipset", st.idx-1)
+		return nil, false
+	}
+	return res.TipSet, true
 }
 
-func (st *StateTracker) NextExpectedStateRoot() (cid.Cid, bool) {
-	defer func() { st.rootIdx += 1 }()
-	if st.rootIdx > len(st.expectedStateRoots)-1 {
-		// didn't find any gas
-		return cid.Undef, false
+func (st *StateTracker) next() (box.Result, bool) {
+	defer func() { st.idx += 1 }()
+	if st.idx > len(st.expected)-1 {
+		return box.Result{}, false
 	}
-	return st.expectedStateRoots[st.rootIdx], true
+	return st.expected[st.idx], true
 }
 
-// write the contents of gm.tracker to a file using the format:
-// GasUnit
-// GasUnit
-// ...
+// Record writes the results tracked so far to the expectations of the test in the most specific profile, in the
+// directory named by ValidationDataEnvVar.
 func (st *StateTracker) Record() {
-	file := getTestDataFilePath(st.T)
-	f, err := os.OpenFile(file, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
-	if err != nil {
-		st.T.Log(err)
-		return
+	key := KeyFromTest(st.T)
+	checkKeyCollision(st.T, st.profiles[0], key)
+
+	dataPath := os.Getenv(ValidationDataEnvVar)
+	if dataPath == "" {
+		st.T.Fatalf("failed to find validation data path, make sure %s is set", ValidationDataEnvVar)
 	}
-	defer func() { _ = f.Close() }()
-	enc := json.NewEncoder(f)
 
+	exp := box.NewExpectations()
 	for e := st.tracker.Front(); e != nil; e = e.Next() {
-		switch ele := e.Value.(type) {
-		case types.ApplyMessageResult:
-			if err := enc.Encode(ele); err != nil {
-				st.T.Fatal(err)
-			}
-		case types.ApplyTipSetResult:
-			if err := enc.Encode(ele); err != nil {
-				st.T.Fatal(err)
-			}
-		default:
-			st.T.Fatalf("Unknown type: %T", ele)
+		if err := exp.Add(e.Value.(types.Trackable)); err != nil {
+			st.T.Fatal(err)
 		}
 	}
+	if err := box.Write(dataPath, st.profiles[0], key.String(), exp); err != nil {
+		st.T.Log(err)
+	}
 }
 
-func LoadDataForTest(t testing.TB) (gasUsed []types.GasUnits, stateRoots []cid.Cid) {
-	fileName := filenameFromTest(t)
-	data, found := box.Get(fileName)
-	if !found {
-		t.Logf("WARNING (does NOT indicate test failure): can't find file: %s", fileName)
-		// return an empty slice here since `NextExpectedGas` performs bounds checking
-		return []types.GasUnits{}, []cid.Cid{}
+// ExpectationStore returns the store named by ExpectationsDirEnvVar, or the store embedded in the box package if unset.
+func ExpectationStore() *box.Store {
+	if dir := os.Getenv(ExpectationsDirEnvVar); dir != "" {
+		return box.DirStore(dir)
 	}
+	return box.Embedded()
+}
 
-	switch v := data.(type) {
-	case types.ApplyMessageResult:
-		gasUsed = append(gasUsed, v.GasUsed())
-		stateRoots = append(stateRoots, v.StateRoot())
-		return
-	case []types.ApplyMessageResult:
-		for _, res := range v {
-			gasUsed = append(gasUsed, res.GasUsed())
-			stateRoots = append(stateRoots, res.StateRoot())
+// LoadDataForTest returns the results recorded for the test `t` in the first of `profiles` of `store` that holds
+// them, along with the name of that profile. The resolution is logged and reported by Resolutions.
+func LoadDataForTest(t testing.TB, store *box.Store, profiles []string) ([]box.Result, string) {
+	key := KeyFromTest(t).String()
+	res := Resolution{Key: key, Profiles: profiles}
+	defer func() { addResolution(res) }()
+
+	for _, profile := range profiles {
+		exp, found, err := store.Get(profile, key)
+		if err != nil {
+			t.Fatal(err)
 		}
-		return
-	case types.ApplyTipSetResult:
-		for _, rect := range v.Receipts {
-			gasUsed = append(gasUsed, rect.GasUsed)
+		if !found {
+			continue
 		}
-		stateRoots = append(stateRoots, v.StateRoot())
-		return
-	case []types.ApplyTipSetResult:
-		for _, res := range v {
-			for _, rect := range res.Receipts {
-				gasUsed = append(gasUsed, rect.GasUsed)
-			}
-			stateRoots = append(stateRoots, res.StateRoot())
+		res.Profile = profile
+		if res.Fallback() {
+			t.Logf("expectations for %s not found in profile %s, falling back to profile %s", key, profiles[0], profile)
+		} else {
+			t.Logf("expectations for %s loaded from profile %s", key, profile)
 		}
-		return
-	default:
-		t.Fatalf("Unknown Test Data Type: %T", v)
+		return exp.Results, profile
 	}
-	panic("unreachable")
+	t.Logf("WARNING (does NOT indicate test failure): can't find expectations for %s in profiles %v", key, profiles)
+	return nil, ""
 }
 
-func getTestDataFilePath(t testing.TB) string {
-	dataPath := os.Getenv(ValidationDataEnvVar)
-	if dataPath == "" {
-		t.Fatalf("failed to find validation data path, make sure %s is set", ValidationDataEnvVar)
+// checkKeyCollision fails the test if its expectations could be confused with those of another test.
+func checkKeyCollision(t testing.TB, profile string, key TestKey) {
+	if key.IsDuplicateSubtest() {
+		t.Fatalf("cannot record expectations for %s: subtest name is not unique within its parent", t.Name())
 	}
-	return filepath.Join(dataPath, filenameFromTest(t))
-}
 
-// return a string containing only letters and number.
-func filenameFromTest(t testing.TB) string {
-	// only want letters and numbers
-	reg, err := regexp.Compile("[^a-zA-Z0-9]+")
-	if err != nil {
-		t.Fatal(err)
+	recorded.Lock()
+	defer recorded.Unlock()
+	p := box.Path(profile, key.String())
+	if prev, ok := recorded.keys[p]; ok {
+		t.Fatalf("cannot record expectations for %s: key %s was already recorded in profile %s by %s", t.Name(), key, profile, prev)
 	}
-	tokens := strings.Split(t.Name(), "/")
-	chainvalTestName := strings.Join(tokens[1:], "")
-	return fmt.Sprintf("/%s", reg.ReplaceAllString(chainvalTestName, ""))
+	recorded.keys[p] = t.Name()
 }